@@ -0,0 +1,93 @@
+/**
+Tests for tzagent, the opt-in client-side tool-execution loop that
+replaces the manual receive-tool_calls/execute/append/re-call dance
+exercised by "it should handle multi-turn parallel tool calls" in
+openai_compatibility_test.go.
+*/
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tensorzero/client/go/tzagent"
+	"tensorzero/client/go/tzstream"
+)
+
+func TestAgent(t *testing.T) {
+	t.Run("should resolve parallel tool calls without a manual loop", func(t *testing.T) {
+		agent := tzagent.New(client)
+		agent.RegisterTool("get_temperature", func(args map[string]any) (string, error) {
+			return "70", nil
+		})
+		agent.RegisterTool("get_humidity", func(args map[string]any) (string, error) {
+			return "30", nil
+		})
+
+		req := openai.ChatCompletionNewParams{
+			Model: "tensorzero::function_name::weather_helper_parallel",
+			Messages: []openai.ChatCompletionMessageParamUnion{
+				{OfSystem: systemMessageWithAssistant(t, "Dr. Mehta")},
+				openai.UserMessage("What is the weather like in Tokyo? Use both the provided `get_temperature` and `get_humidity` tools, then tell me both values."),
+			},
+			ParallelToolCalls: openai.Bool(true),
+		}
+		req.SetExtraFields(map[string]any{
+			"tensorzero::variant_name": "openai",
+		})
+
+		resp, err := agent.Run(ctx, req)
+		require.NoError(t, err, "Agent run failed")
+		assert.Contains(t, resp.Choices[0].Message.Content, "70")
+		assert.Contains(t, resp.Choices[0].Message.Content, "30")
+	})
+
+	t.Run("should surface an error payload for an unregistered tool", func(t *testing.T) {
+		agent := tzagent.New(client)
+		// weather_helper calls get_temperature, which is left unregistered here.
+		agent.RegisterTool("get_humidity", func(args map[string]any) (string, error) {
+			return "30", nil
+		})
+
+		req := openai.ChatCompletionNewParams{
+			Model: "tensorzero::function_name::weather_helper",
+			Messages: []openai.ChatCompletionMessageParamUnion{
+				openai.UserMessage("Hi I'm visiting Brooklyn from Brazil. What's the weather?"),
+			},
+		}
+
+		resp, err := agent.Run(ctx, req)
+		require.NoError(t, err, "Agent run failed")
+		require.NotNil(t, resp, "Agent should still return a final response")
+	})
+
+	t.Run("should stream interleaved tool_calls and content deltas", func(t *testing.T) {
+		agent := tzagent.New(client)
+		agent.RegisterTool("get_temperature", func(args map[string]any) (string, error) {
+			return "70", nil
+		})
+
+		req := openai.ChatCompletionNewParams{
+			Model: "tensorzero::function_name::weather_helper",
+			Messages: []openai.ChatCompletionMessageParamUnion{
+				openai.UserMessage("Hi I'm visiting Brooklyn from Brazil. What's the weather?"),
+			},
+		}
+
+		var sawToolCall, sawText bool
+		err := agent.RunStreaming(ctx, req, func(event tzstream.StreamEvent) {
+			switch event.(type) {
+			case tzstream.ToolCallDelta:
+				sawToolCall = true
+			case tzstream.TextDelta:
+				sawText = true
+			}
+		})
+		require.NoError(t, err, "Agent streaming run failed")
+		assert.True(t, sawToolCall, "Should have seen at least one tool call delta")
+		assert.True(t, sawText, "Should have seen the final turn's text content")
+	})
+}
@@ -0,0 +1,84 @@
+/**
+Tests for routing /v1/audio/transcriptions through a TensorZero function
+(rather than the tensorzero::model_name::... short-hand already covered
+by TestAudio), so a transcription function can pick between Whisper-family
+provider variants, and for sending feedback against the resulting
+inference using the same inference_id the chat path produces.
+*/
+
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/openai/openai-go/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tensorzero/client/go/tzopenai"
+)
+
+func sendFeedback(t *testing.T, body map[string]interface{}) (map[string]interface{}, error) {
+	t.Helper()
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal feedback body: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", "http://127.0.0.1:3000/feedback", bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create feedback request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("feedback request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("feedback HTTP error! status: %d", resp.StatusCode)
+	}
+
+	var responseBody map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&responseBody); err != nil {
+		return nil, fmt.Errorf("failed to decode feedback response: %w", err)
+	}
+	return responseBody, nil
+}
+
+func TestAudioTranscriptionFunction(t *testing.T) {
+	t.Run("should route a transcription function to a variant and accept feedback", func(t *testing.T) {
+		episodeID, _ := uuid.NewV7()
+
+		req := &openai.AudioTranscriptionNewParams{
+			Model: "tensorzero::function_name::transcribe_helper",
+		}
+		tzopenai.TensorZeroOptions{
+			EpisodeID:   episodeID,
+			VariantName: "whisper_cpp_local",
+		}.ApplyToTranscription(req)
+
+		resp, err := client.Audio.Transcriptions.New(ctx, *req)
+		require.NoError(t, err, "API request failed")
+		assert.NotEmpty(t, resp.Text, "Transcription should have text")
+
+		rawInferenceID, ok := resp.AsTranscription().JSON.ExtraFields["inference_id"]
+		require.True(t, ok, "Response does not contain an inference_id")
+		var inferenceID string
+		require.NoError(t, json.Unmarshal([]byte(rawInferenceID.Raw()), &inferenceID))
+
+		feedbackResp, err := sendFeedback(t, map[string]interface{}{
+			"inference_id": inferenceID,
+			"metric_name":  "transcript_quality",
+			"value":        true,
+		})
+		require.NoError(t, err, "Feedback request failed")
+		assert.NotEmpty(t, feedbackResp["feedback_id"], "Feedback response should include a feedback_id")
+	})
+}
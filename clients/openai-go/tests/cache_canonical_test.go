@@ -0,0 +1,120 @@
+/**
+Tests for the "canonical" cache key_strategy, which derives the cache
+key from a JCS-canonicalized projection of the resolved prompt, tool
+schema, and decoding params instead of the raw request bytes, so that
+semantically equivalent requests share a cache entry. Replaces timing
+heuristics with the tensorzero::cache_status response extra.
+
+Field order/whitespace variation can't be produced through the typed SDK
+params (Go struct field order is fixed, and json.Marshal on a map sorts
+keys), so these tests send hand-written JSON bodies directly against the
+gateway, the same way sendRequestTzGateway does but with a caller-supplied
+raw body instead of a marshaled map.
+*/
+
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sendRawJSONTzGateway(t *testing.T, rawJSON string) (map[string]interface{}, error) {
+	t.Helper()
+	req, err := http.NewRequest("POST", "http://127.0.0.1:3000/openai/v1/chat/completions", strings.NewReader(rawJSON))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer donotuse")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP error! status: %d", resp.StatusCode)
+	}
+
+	var responseBody map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&responseBody); err != nil {
+		return nil, fmt.Errorf("failed to decode response body: %w", err)
+	}
+	return responseBody, nil
+}
+
+func TestCanonicalCache(t *testing.T) {
+	t.Run("should hit the same entry for differently-ordered and -whitespaced equivalent requests", func(t *testing.T) {
+		firstBody := `{
+			"model": "tensorzero::function_name::basic_test",
+			"messages": [
+				{"role": "system", "content": [{"type": "text", "tensorzero::arguments": {"assistant_name": "Alfred Pennyworth"}}]},
+				{"role": "user", "content": "Hello"}
+			],
+			"temperature": 0.4,
+			"tensorzero::cache_options": {"enabled": "on", "key_strategy": "canonical"}
+		}`
+
+		// Same request, but with top-level fields reordered and extra
+		// whitespace thrown in - a byte-for-byte ("exact") cache key
+		// strategy would treat this as a different request entirely.
+		secondBody := `{
+			"tensorzero::cache_options"   :   {"key_strategy": "canonical", "enabled": "on"},
+			"temperature": 0.4,
+			"messages": [
+				{"content": [{"tensorzero::arguments": {"assistant_name": "Alfred Pennyworth"}, "type": "text"}], "role": "system"},
+				{"content": "Hello", "role": "user"}
+			],
+
+			"model": "tensorzero::function_name::basic_test"
+		}`
+
+		firstResp, err := sendRawJSONTzGateway(t, firstBody)
+		require.NoError(t, err, "First request failed")
+		assert.Equal(t, "miss", firstResp["tensorzero::cache_status"])
+
+		secondResp, err := sendRawJSONTzGateway(t, secondBody)
+		require.NoError(t, err, "Second request failed")
+		assert.Equal(t, "hit", secondResp["tensorzero::cache_status"], "Reordered/rewhitespaced request should hit the canonical cache entry")
+
+		firstContent := firstResp["choices"].([]interface{})[0].(map[string]interface{})["message"].(map[string]interface{})["content"]
+		secondContent := secondResp["choices"].([]interface{})[0].(map[string]interface{})["message"].(map[string]interface{})["content"]
+		assert.Equal(t, firstContent, secondContent)
+	})
+
+	t.Run("should scope the canonical projection to included fields", func(t *testing.T) {
+		baseBody := `{
+			"model": "tensorzero::function_name::basic_test",
+			"messages": [{"role": "user", "content": "Hello"}],
+			"tensorzero::tags": {"request": "one"},
+			"tensorzero::cache_options": {"enabled": "on", "key_strategy": "canonical", "include_fields": ["messages"]}
+		}`
+
+		firstResp, err := sendRawJSONTzGateway(t, baseBody)
+		require.NoError(t, err, "First request failed")
+		assert.Equal(t, "miss", firstResp["tensorzero::cache_status"])
+
+		// Differs only in tensorzero::tags, which isn't in include_fields,
+		// so the canonical projection - and therefore the cache key -
+		// should be unaffected.
+		sameProjectionBody := strings.Replace(baseBody, `"request": "one"`, `"request": "two"`, 1)
+		sameProjectionResp, err := sendRawJSONTzGateway(t, sameProjectionBody)
+		require.NoError(t, err, "Second request failed")
+		assert.Equal(t, "hit", sameProjectionResp["tensorzero::cache_status"], "A field outside include_fields shouldn't affect the cache key")
+
+		// Differs in messages, which is in include_fields, so this one
+		// must miss.
+		differentProjectionBody := strings.Replace(baseBody, `"Hello"`, `"Goodbye"`, 1)
+		differentProjectionResp, err := sendRawJSONTzGateway(t, differentProjectionBody)
+		require.NoError(t, err, "Third request failed")
+		assert.Equal(t, "miss", differentProjectionResp["tensorzero::cache_status"], "A field inside include_fields should still affect the cache key")
+	})
+}
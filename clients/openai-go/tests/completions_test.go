@@ -0,0 +1,190 @@
+/**
+Tests for the legacy OpenAI `/v1/completions` endpoint routed through
+TensorZero (`tensorzero::model_name::...`), alongside the
+`tensorzero::episode_id`, `tensorzero::include_raw_usage`, and
+`tensorzero::include_raw_response` extras already covered for chat
+completions.
+*/
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/openai/openai-go/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tensorzero/client/go/tzopenai"
+)
+
+func TestCompletions(t *testing.T) {
+	t.Run("should handle basic completion inference", func(t *testing.T) {
+		episodeID, _ := uuid.NewV7()
+
+		req := &openai.CompletionNewParams{
+			Model:  "tensorzero::model_name::dummy::echo",
+			Prompt: openai.CompletionNewParamsPromptUnion{OfString: openai.String("Hello")},
+		}
+		tzopenai.TensorZeroOptions{EpisodeID: episodeID}.ApplyToCompletion(req)
+
+		resp, err := client.Completions.New(ctx, *req)
+		require.NoError(t, err, "API request failed")
+
+		require.NotEmpty(t, resp.Choices, "Response should have choices")
+		assert.NotEmpty(t, resp.Choices[0].Text, "Completion text should not be empty")
+	})
+
+	t.Run("should accept a prompt array", func(t *testing.T) {
+		req := &openai.CompletionNewParams{
+			Model: "tensorzero::model_name::dummy::echo",
+			Prompt: openai.CompletionNewParamsPromptUnion{
+				OfArrayOfStrings: []string{"Hello", "Continue"},
+			},
+		}
+
+		resp, err := client.Completions.New(ctx, *req)
+		require.NoError(t, err, "API request failed")
+		require.NotEmpty(t, resp.Choices, "Response should have choices")
+	})
+
+	t.Run("should return raw usage and raw response when requested", func(t *testing.T) {
+		episodeID, _ := uuid.NewV7()
+
+		req := &openai.CompletionNewParams{
+			Model:  "tensorzero::model_name::gpt-4o-mini-2024-07-18",
+			Prompt: openai.CompletionNewParamsPromptUnion{OfString: openai.String("Hello")},
+			Echo:   openai.Bool(true),
+		}
+		tzopenai.TensorZeroOptions{
+			EpisodeID:          episodeID,
+			IncludeRawUsage:    true,
+			IncludeRawResponse: true,
+		}.ApplyToCompletion(req)
+
+		resp, err := client.Completions.New(ctx, *req)
+		require.NoError(t, err, "API request failed")
+
+		rawUsage, err := tzopenai.CompletionRawUsage(resp)
+		require.NoError(t, err)
+		require.NotEmpty(t, rawUsage, "tensorzero_raw_usage should have at least one entry")
+
+		rawResponse, err := tzopenai.CompletionRawResponse(resp)
+		require.NoError(t, err)
+		require.NotEmpty(t, rawResponse, "tensorzero_raw_response should have at least one entry")
+	})
+
+	t.Run("should stream completion chunks", func(t *testing.T) {
+		episodeID, _ := uuid.NewV7()
+
+		req := &openai.CompletionNewParams{
+			Model:  "tensorzero::model_name::dummy::echo",
+			Prompt: openai.CompletionNewParamsPromptUnion{OfString: openai.String("Hello")},
+		}
+		tzopenai.TensorZeroOptions{EpisodeID: episodeID}.ApplyToCompletion(req)
+
+		stream := client.Completions.NewStreaming(ctx, *req)
+		require.NotNil(t, stream, "Streaming response should not be nil")
+
+		var gotText bool
+		for stream.Next() {
+			chunk := stream.Current()
+			if len(chunk.Choices) > 0 && chunk.Choices[0].Text != "" {
+				gotText = true
+			}
+		}
+		require.NoError(t, stream.Err(), "Stream encountered an error")
+		assert.True(t, gotText, "Streaming completion should include at least one chunk of text")
+	})
+
+	t.Run("should handle completion with cache", func(t *testing.T) {
+		req := &openai.CompletionNewParams{
+			Model:  "tensorzero::model_name::dummy::echo",
+			Prompt: openai.CompletionNewParamsPromptUnion{OfString: openai.String("Hello")},
+		}
+
+		resp, err := client.Completions.New(ctx, *req)
+		require.NoError(t, err, "Unexpected error while getting completion")
+		require.NotEmpty(t, resp.Choices)
+
+		tzopenai.TensorZeroOptions{
+			CacheOptions: &tzopenai.CacheOptions{Enabled: "on"},
+		}.ApplyToCompletion(req)
+
+		cachedResp, err := client.Completions.New(ctx, *req)
+		require.NoError(t, err, "Unexpected error while getting cached completion")
+		require.NotEmpty(t, cachedResp.Choices)
+		assert.Equal(t, resp.Choices[0].Text, cachedResp.Choices[0].Text)
+	})
+
+	t.Run("should handle null completion response", func(t *testing.T) {
+		req := &openai.CompletionNewParams{
+			Model:  "tensorzero::model_name::dummy::null",
+			Prompt: openai.CompletionNewParamsPromptUnion{OfString: openai.String("No yapping!")},
+		}
+
+		resp, err := client.Completions.New(ctx, *req)
+		require.NoError(t, err, "API request failed")
+		require.NotEmpty(t, resp.Choices, "Response should have choices")
+		assert.Empty(t, resp.Choices[0].Text, "Completion text should be empty")
+	})
+
+	t.Run("should route logprobs and echo through the raw response envelope", func(t *testing.T) {
+		req := &openai.CompletionNewParams{
+			Model:    "tensorzero::model_name::gpt-4o-mini-2024-07-18",
+			Prompt:   openai.CompletionNewParamsPromptUnion{OfString: openai.String("Hello")},
+			Echo:     openai.Bool(true),
+			Logprobs: openai.Int(1),
+		}
+		tzopenai.TensorZeroOptions{IncludeRawResponse: true}.ApplyToCompletion(req)
+
+		resp, err := client.Completions.New(ctx, *req)
+		require.NoError(t, err, "API request failed")
+		require.NotEmpty(t, resp.Choices)
+		assert.NotNil(t, resp.Choices[0].Logprobs, "Logprobs should be present when requested")
+		assert.Contains(t, resp.Choices[0].Text, "Hello", "Echo should include the original prompt")
+
+		rawResponse, err := tzopenai.CompletionRawResponse(resp)
+		require.NoError(t, err)
+		assert.NotEmpty(t, rawResponse, "tensorzero_raw_response should have at least one entry")
+	})
+
+	t.Run("should return n choices", func(t *testing.T) {
+		req := &openai.CompletionNewParams{
+			Model:  "tensorzero::model_name::dummy::echo",
+			Prompt: openai.CompletionNewParamsPromptUnion{OfString: openai.String("Hello")},
+			N:      openai.Int(2),
+		}
+
+		resp, err := client.Completions.New(ctx, *req)
+		require.NoError(t, err, "API request failed")
+		assert.Len(t, resp.Choices, 2, "Completion should return the requested number of choices")
+	})
+
+	t.Run("should handle a nonexistent function", func(t *testing.T) {
+		req := &openai.CompletionNewParams{
+			Model:  "tensorzero::function_name::does_not_exist",
+			Prompt: openai.CompletionNewParamsPromptUnion{OfString: openai.String("Hello")},
+		}
+
+		_, err := client.Completions.New(ctx, *req)
+		require.Error(t, err, "Expected an error for a nonexistent function")
+
+		var apiErr *openai.Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, 404, apiErr.StatusCode)
+	})
+
+	t.Run("should handle a missing model", func(t *testing.T) {
+		req := &openai.CompletionNewParams{
+			Prompt: openai.CompletionNewParamsPromptUnion{OfString: openai.String("Hello")},
+		}
+
+		_, err := client.Completions.New(ctx, *req)
+		require.Error(t, err, "Expected an error for a missing model")
+
+		var apiErr *openai.Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, 400, apiErr.StatusCode)
+	})
+}
@@ -0,0 +1,84 @@
+/**
+Tests for routing /v1/images/generations through a TensorZero function
+(rather than the tensorzero::model_name::... short-hand already covered
+by TestImages), episode continuity across a generate-then-describe flow,
+and the 400 rejection of a size unsupported by the chosen variant.
+*/
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/openai/openai-go/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tensorzero/client/go/tzopenai"
+)
+
+func TestImageGenerationFunction(t *testing.T) {
+	t.Run("should route an image function to a provider variant", func(t *testing.T) {
+		episodeID, _ := uuid.NewV7()
+
+		req := &openai.ImageGenerateParams{
+			Model:  "tensorzero::function_name::image_helper",
+			Prompt: "a TensorZero mascot",
+		}
+		tzopenai.TensorZeroOptions{
+			EpisodeID:   episodeID,
+			VariantName: "dalle",
+		}.ApplyToImageGenerate(req)
+
+		resp, err := client.Images.Generate(ctx, *req)
+		require.NoError(t, err, "API request failed")
+		require.NotEmpty(t, resp.Data, "Response should have image data")
+	})
+
+	t.Run("should keep the generated image available to a follow-up chat inference in the same episode", func(t *testing.T) {
+		episodeID, _ := uuid.NewV7()
+
+		imageReq := &openai.ImageGenerateParams{
+			Model:  "tensorzero::function_name::image_helper",
+			Prompt: "a red circle on a white background",
+		}
+		tzopenai.TensorZeroOptions{EpisodeID: episodeID}.ApplyToImageGenerate(imageReq)
+
+		imageResp, err := client.Images.Generate(ctx, *imageReq)
+		require.NoError(t, err, "Image generation failed")
+		require.NotEmpty(t, imageResp.Data)
+		require.NotEmpty(t, imageResp.Data[0].URL, "Generated image should be stored and referenceable by URL")
+
+		usrMsg := openai.UserMessage([]openai.ChatCompletionContentPartUnionParam{
+			openai.ImageContentPart(openai.ChatCompletionContentPartImageImageURLParam{
+				URL: imageResp.Data[0].URL,
+			}),
+			openai.TextContentPart("Describe the image you just generated"),
+		})
+		describeReq := &openai.ChatCompletionNewParams{
+			Model:    "tensorzero::function_name::basic_test",
+			Messages: []openai.ChatCompletionMessageParamUnion{usrMsg},
+		}
+		tzopenai.TensorZeroOptions{EpisodeID: episodeID}.Apply(describeReq)
+
+		describeResp, err := client.Chat.Completions.New(ctx, *describeReq)
+		require.NoError(t, err, "Describe-the-image request failed")
+		assert.NotEmpty(t, describeResp.Choices[0].Message.Content)
+	})
+
+	t.Run("should reject an unsupported size with a 400", func(t *testing.T) {
+		req := &openai.ImageGenerateParams{
+			Model:  "tensorzero::function_name::image_helper",
+			Prompt: "a TensorZero mascot",
+			Size:   "1x1",
+		}
+		tzopenai.TensorZeroOptions{VariantName: "dalle"}.ApplyToImageGenerate(req)
+
+		_, err := client.Images.Generate(ctx, *req)
+		require.Error(t, err, "Expected an error for an unsupported size")
+
+		var apiErr *openai.Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, 400, apiErr.StatusCode)
+	})
+}
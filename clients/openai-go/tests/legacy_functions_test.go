@@ -0,0 +1,89 @@
+/**
+Tests for the deprecated OpenAI `functions`/`function_call` request shape,
+which the gateway translates into `tools`/`tool_choice` for backward
+compatibility with old OpenAI SDK, LangChain, and LlamaIndex callers.
+Sent directly against the gateway (rather than through the Go SDK types,
+which dropped the legacy fields) via sendRequestTzGateway.
+*/
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLegacyFunctionCallShape(t *testing.T) {
+	t.Run("should translate functions/function_call into a tool call", func(t *testing.T) {
+		body := map[string]interface{}{
+			"model": "tensorzero::function_name::weather_helper",
+			"messages": []map[string]interface{}{
+				{
+					"role":    "user",
+					"content": "Hi I'm visiting Brooklyn from Brazil. What's the weather?",
+				},
+			},
+			"functions": []map[string]interface{}{
+				{
+					"name":        "get_temperature",
+					"description": "Get the current temperature for a location",
+					"parameters": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"location": map[string]interface{}{"type": "string"},
+							"units":    map[string]interface{}{"type": "string", "enum": []string{"fahrenheit", "celsius"}},
+						},
+						"required": []string{"location"},
+					},
+				},
+			},
+			"function_call": "auto",
+		}
+
+		resp, err := sendRequestTzGateway(t, body)
+		require.NoError(t, err, "API request failed")
+
+		message := resp["choices"].([]interface{})[0].(map[string]interface{})["message"].(map[string]interface{})
+		toolCalls, ok := message["tool_calls"].([]interface{})
+		require.True(t, ok, "Response should use the modern tool_calls shape by default")
+		require.Len(t, toolCalls, 1)
+
+		function := toolCalls[0].(map[string]interface{})["function"].(map[string]interface{})
+		assert.Equal(t, "get_temperature", function["name"])
+	})
+
+	t.Run("should emit the legacy function_call response shape when requested", func(t *testing.T) {
+		body := map[string]interface{}{
+			"model": "tensorzero::function_name::weather_helper",
+			"messages": []map[string]interface{}{
+				{
+					"role":    "user",
+					"content": "Hi I'm visiting Brooklyn from Brazil. What's the weather?",
+				},
+			},
+			"functions": []map[string]interface{}{
+				{
+					"name":        "get_temperature",
+					"description": "Get the current temperature for a location",
+					"parameters": map[string]interface{}{
+						"type":       "object",
+						"properties": map[string]interface{}{"location": map[string]interface{}{"type": "string"}},
+						"required":   []string{"location"},
+					},
+				},
+			},
+			"function_call": map[string]interface{}{"name": "get_temperature"},
+			"tensorzero::legacy_function_call_response": true,
+		}
+
+		resp, err := sendRequestTzGateway(t, body)
+		require.NoError(t, err, "API request failed")
+
+		message := resp["choices"].([]interface{})[0].(map[string]interface{})["message"].(map[string]interface{})
+		functionCall, ok := message["function_call"].(map[string]interface{})
+		require.True(t, ok, "Response should use the legacy function_call shape when requested")
+		assert.Equal(t, "get_temperature", functionCall["name"])
+	})
+}
@@ -0,0 +1,191 @@
+/**
+End-to-end coverage for the non-chat OpenAI-compatible surfaces that
+TensorZero exposes: embeddings, audio transcription/translation/speech,
+and image generation. Each uses the same TensorZeroOptions helper as chat
+completions to pin an episode/variant and recover the raw response.
+*/
+
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/openai/openai-go/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tensorzero/client/go/tzopenai"
+)
+
+func TestEmbeddings(t *testing.T) {
+	t.Run("should create an embedding with raw response", func(t *testing.T) {
+		episodeID, _ := uuid.NewV7()
+
+		req := &openai.EmbeddingNewParams{
+			Model: "tensorzero::model_name::dummy::embedding",
+			Input: openai.EmbeddingNewParamsInputUnion{OfString: openai.String("Hello, world!")},
+		}
+		tzopenai.TensorZeroOptions{
+			EpisodeID:          episodeID,
+			IncludeRawResponse: true,
+			CacheOptions:       &tzopenai.CacheOptions{Enabled: "on"},
+		}.ApplyToEmbedding(req)
+
+		resp, err := client.Embeddings.New(ctx, *req)
+		require.NoError(t, err, "API request failed")
+		require.NotEmpty(t, resp.Data, "Response should have embedding data")
+
+		rawResponse, err := tzopenai.EmbeddingRawResponse(resp)
+		require.NoError(t, err)
+		assert.NotEmpty(t, rawResponse, "tensorzero_raw_response should have at least one entry")
+	})
+
+	t.Run("should embed a batch of strings", func(t *testing.T) {
+		req := &openai.EmbeddingNewParams{
+			Model: "tensorzero::model_name::dummy::embedding",
+			Input: openai.EmbeddingNewParamsInputUnion{
+				OfArrayOfStrings: []string{"Hello, world!", "Goodbye, world!"},
+			},
+		}
+
+		resp, err := client.Embeddings.New(ctx, *req)
+		require.NoError(t, err, "API request failed")
+		require.Len(t, resp.Data, 2, "Response should have one embedding per input")
+	})
+
+	t.Run("should decode a base64-encoded embedding", func(t *testing.T) {
+		req := &openai.EmbeddingNewParams{
+			Model:          "tensorzero::model_name::dummy::embedding",
+			Input:          openai.EmbeddingNewParamsInputUnion{OfString: openai.String("Hello, world!")},
+			EncodingFormat: openai.EmbeddingNewParamsEncodingFormatBase64,
+		}
+
+		resp, err := client.Embeddings.New(ctx, *req)
+		require.NoError(t, err, "API request failed")
+		require.NotEmpty(t, resp.Data)
+		assert.NotEmpty(t, resp.Data[0].Embedding, "SDK should decode base64 embeddings back into floats")
+	})
+
+	t.Run("should return zero usage on a cache hit", func(t *testing.T) {
+		req := &openai.EmbeddingNewParams{
+			Model: "tensorzero::model_name::dummy::embedding",
+			Input: openai.EmbeddingNewParamsInputUnion{OfString: openai.String("cache me")},
+		}
+
+		_, err := client.Embeddings.New(ctx, *req)
+		require.NoError(t, err, "Unexpected error on the warming request")
+
+		tzopenai.TensorZeroOptions{
+			CacheOptions: &tzopenai.CacheOptions{Enabled: "on"},
+		}.ApplyToEmbedding(req)
+
+		cachedResp, err := client.Embeddings.New(ctx, *req)
+		require.NoError(t, err, "Unexpected error on the cached request")
+		assert.Equal(t, int64(0), cachedResp.Usage.TotalTokens, "Cached embedding should report zero usage")
+	})
+
+	t.Run("should truncate embeddings via the dimensions parameter", func(t *testing.T) {
+		req := &openai.EmbeddingNewParams{
+			Model:      "tensorzero::model_name::dummy::embedding",
+			Input:      openai.EmbeddingNewParamsInputUnion{OfString: openai.String("Hello, world!")},
+			Dimensions: openai.Int(4),
+		}
+
+		resp, err := client.Embeddings.New(ctx, *req)
+		require.NoError(t, err, "API request failed")
+		require.NotEmpty(t, resp.Data)
+		assert.Len(t, resp.Data[0].Embedding, 4, "Embedding should be truncated to the requested dimensions")
+	})
+}
+
+func TestAudio(t *testing.T) {
+	t.Run("should transcribe audio", func(t *testing.T) {
+		req := &openai.AudioTranscriptionNewParams{
+			Model: "tensorzero::model_name::dummy::transcribe",
+		}
+		tzopenai.TensorZeroOptions{IncludeRawResponse: true}.ApplyToTranscription(req)
+
+		resp, err := client.Audio.Transcriptions.New(ctx, *req)
+		require.NoError(t, err, "API request failed")
+		assert.NotEmpty(t, resp.Text, "Transcription should have text")
+	})
+
+	t.Run("should propagate the episode ID to a transcription", func(t *testing.T) {
+		episodeID, _ := uuid.NewV7()
+
+		req := &openai.AudioTranscriptionNewParams{
+			Model: "tensorzero::model_name::dummy::transcribe",
+		}
+		tzopenai.TensorZeroOptions{EpisodeID: episodeID}.ApplyToTranscription(req)
+
+		resp, err := client.Audio.Transcriptions.New(ctx, *req)
+		require.NoError(t, err, "API request failed")
+
+		rawEpisodeID, ok := resp.AsTranscription().JSON.ExtraFields["episode_id"]
+		require.True(t, ok, "Response does not contain an episode_id")
+		var responseEpisodeID string
+		require.NoError(t, json.Unmarshal([]byte(rawEpisodeID.Raw()), &responseEpisodeID))
+		assert.Equal(t, episodeID.String(), responseEpisodeID)
+	})
+
+	t.Run("should populate verbose_json segments", func(t *testing.T) {
+		req := &openai.AudioTranscriptionNewParams{
+			Model:          "tensorzero::model_name::dummy::transcribe",
+			ResponseFormat: openai.AudioResponseFormatVerboseJSON,
+		}
+
+		resp, err := client.Audio.Transcriptions.New(ctx, *req)
+		require.NoError(t, err, "API request failed")
+		assert.NotEmpty(t, resp.Segments, "verbose_json response should include segments")
+	})
+
+	t.Run("should translate audio", func(t *testing.T) {
+		req := &openai.AudioTranslationNewParams{
+			Model: "tensorzero::model_name::dummy::transcribe",
+		}
+		tzopenai.TensorZeroOptions{}.ApplyToTranslation(req)
+
+		resp, err := client.Audio.Translations.New(ctx, *req)
+		require.NoError(t, err, "API request failed")
+		assert.NotEmpty(t, resp.Text, "Translation should have text")
+	})
+
+	t.Run("should synthesize speech", func(t *testing.T) {
+		req := &openai.AudioSpeechNewParams{
+			Model: "tensorzero::model_name::dummy::speech",
+			Input: "Hello, world!",
+			Voice: "alloy",
+		}
+		tzopenai.TensorZeroOptions{}.ApplyToSpeech(req)
+
+		resp, err := client.Audio.Speech.New(ctx, *req)
+		require.NoError(t, err, "API request failed")
+		defer resp.Body.Close()
+	})
+}
+
+func TestImages(t *testing.T) {
+	t.Run("should generate an image with raw response", func(t *testing.T) {
+		episodeID, _ := uuid.NewV7()
+
+		req := &openai.ImageGenerateParams{
+			Model:  "tensorzero::model_name::dummy::image",
+			Prompt: "a TensorZero mascot",
+		}
+		tzopenai.TensorZeroOptions{
+			EpisodeID:          episodeID,
+			IncludeRawResponse: true,
+		}.ApplyToImageGenerate(req)
+
+		resp, err := client.Images.Generate(ctx, *req)
+		require.NoError(t, err, "API request failed")
+		require.NotEmpty(t, resp.Data, "Response should have image data")
+		assert.True(t, resp.Data[0].B64JSON != "" || resp.Data[0].URL != "",
+			"Image response should include either base64 data or a URL")
+
+		rawResponse, err := tzopenai.ImageRawResponse(resp)
+		require.NoError(t, err)
+		assert.NotEmpty(t, rawResponse, "tensorzero_raw_response should have at least one entry")
+	})
+}
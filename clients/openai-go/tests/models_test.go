@@ -0,0 +1,41 @@
+/**
+Tests for GET /openai/v1/models, which enumerates every configured
+TensorZero function (as `tensorzero::function_name::<name>`) and model (as
+`tensorzero::model_name::<name>`) so that OpenAI SDKs can discover routable
+targets instead of hardcoding them.
+*/
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModels(t *testing.T) {
+	t.Run("should list configured functions and models", func(t *testing.T) {
+		page := client.Models.ListAutoPaging(ctx)
+
+		var ids []string
+		for page.Next() {
+			ids = append(ids, page.Current().ID)
+		}
+		require.NoError(t, page.Err())
+
+		assert.Contains(t, ids, "tensorzero::function_name::basic_test")
+		assert.Contains(t, ids, "tensorzero::function_name::json_success")
+		assert.Contains(t, ids, "tensorzero::function_name::null_chat")
+		assert.Contains(t, ids, "tensorzero::model_name::dummy::echo_extra_info")
+	})
+
+	t.Run("should fetch a single function by id", func(t *testing.T) {
+		model, err := client.Models.Get(ctx, "tensorzero::function_name::basic_test")
+		require.NoError(t, err, "API request failed")
+
+		assert.Equal(t, "tensorzero::function_name::basic_test", model.ID)
+		assert.NotEmpty(t, model.OwnedBy, "Model should report an owning provider type")
+		assert.NotZero(t, model.Created, "Model should report a created timestamp")
+	})
+}
@@ -28,12 +28,13 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/openai/openai-go"
-	"github.com/openai/openai-go/option"
-	"github.com/openai/openai-go/packages/param"
-	"github.com/openai/openai-go/shared/constant"
+	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/option"
+	"github.com/openai/openai-go/v3/packages/param"
+	"github.com/openai/openai-go/v3/shared/constant"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"tensorzero/client/go/tzopenai"
 )
 
 var (
@@ -55,7 +56,7 @@ func TestMain(m *testing.M) {
 func systemMessageWithAssistant(t *testing.T, assistant_name string) *openai.ChatCompletionSystemMessageParam {
 	t.Helper()
 
-	sysMsg := param.OverrideObj[openai.ChatCompletionSystemMessageParam](map[string]interface{}{
+	sysMsg := param.Override[openai.ChatCompletionSystemMessageParam](map[string]interface{}{
 		"content": []map[string]interface{}{
 			{
 				"type": "text",
@@ -69,12 +70,27 @@ func systemMessageWithAssistant(t *testing.T, assistant_name string) *openai.Cha
 	return &sysMsg
 }
 
+// mergeExtraFields returns existing with fields layered on top, so a
+// second SetExtraFields call on the same request (e.g. adding a variant
+// name after an episode ID) doesn't silently wipe the first one -
+// SetExtraFields itself replaces the whole map rather than merging.
+func mergeExtraFields(existing, fields map[string]any) map[string]any {
+	merged := make(map[string]any, len(existing)+len(fields))
+	for key, value := range existing {
+		merged[key] = value
+	}
+	for key, value := range fields {
+		merged[key] = value
+	}
+	return merged
+}
+
 func addEpisodeIDToRequest(t *testing.T, req *openai.ChatCompletionNewParams, episodeID uuid.UUID) {
 	t.Helper()
 	// Add the episode ID to the request as an extra field
-	req.WithExtraFields(map[string]any{
+	req.SetExtraFields(mergeExtraFields(req.ExtraFields(), map[string]any{
 		"tensorzero::episode_id": episodeID.String(),
-	})
+	}))
 }
 
 func sendRequestTzGateway(t *testing.T, body map[string]interface{}) (map[string]interface{}, error) {
@@ -128,10 +144,10 @@ func TestTags(t *testing.T) {
 			Messages:    messages,
 			Temperature: openai.Float(0.4),
 		}
-		req.WithExtraFields(map[string]any{
+		req.SetExtraFields(mergeExtraFields(req.ExtraFields(), map[string]any{
 			"tensorzero::episode_id": episodeID.String(),
 			"tensorzero::tags":       map[string]any{"foo": "bar"},
-		})
+		}))
 
 		// Send API request
 		resp, err := client.Chat.Completions.New(ctx, *req)
@@ -174,10 +190,10 @@ func TestMultiStep(t *testing.T) {
 			Messages:    messages,
 			Temperature: openai.Float(0.4),
 		}
-		req.WithExtraFields(map[string]any{
+		req.SetExtraFields(mergeExtraFields(req.ExtraFields(), map[string]any{
 			"tensorzero::episode_id":   episodeID.String(),
 			"tensorzero::variant_name": "test",
-		})
+		}))
 
 		// Send API request
 		resp, err := client.Chat.Completions.New(ctx, *req)
@@ -216,10 +232,10 @@ func TestMultiStep(t *testing.T) {
 			Messages:    messages2,
 			Temperature: openai.Float(0.4),
 		}
-		req2.WithExtraFields(map[string]any{
+		req2.SetExtraFields(mergeExtraFields(req2.ExtraFields(), map[string]any{
 			"tensorzero::episode_id":   episodeID.String(),
 			"tensorzero::variant_name": "test2",
-		})
+		}))
 
 		// Send API request
 		resp2, err := client.Chat.Completions.New(ctx, *req2)
@@ -267,9 +283,9 @@ func TestBasicInference(t *testing.T) {
 			Messages:    messages,
 			Temperature: openai.Float(0.4),
 		}
-		req.WithExtraFields(map[string]any{
+		req.SetExtraFields(mergeExtraFields(req.ExtraFields(), map[string]any{
 			"episode_id": episodeID.String(), //old format
-		})
+		}))
 
 		// Send API request
 		resp, err := client.Chat.Completions.New(ctx, *req)
@@ -402,12 +418,12 @@ func TestBasicInference(t *testing.T) {
 		time.Sleep(time.Second)
 
 		// Second request (cached)
-		req.WithExtraFields(map[string]any{
+		req.SetExtraFields(mergeExtraFields(req.ExtraFields(), map[string]any{
 			"tensorzero::*.tomltions": map[string]any{
 				"max_age_s": 10,
 				"enabled":   "on",
 			},
-		})
+		}))
 
 		cachedResp, err := client.Chat.Completions.New(ctx, *req)
 		require.NoError(t, err, "Unexpected error while getting cached completion")
@@ -426,7 +442,7 @@ func TestBasicInference(t *testing.T) {
 	t.Run("it should handle JSON success with non-deprecated format", func(t *testing.T) {
 		episodeID, _ := uuid.NewV7()
 
-		sysMsg := param.OverrideObj[openai.ChatCompletionSystemMessageParam](map[string]interface{}{
+		sysMsg := param.Override[openai.ChatCompletionSystemMessageParam](map[string]interface{}{
 			"content": []map[string]interface{}{
 				{
 					"type": "text",
@@ -438,7 +454,7 @@ func TestBasicInference(t *testing.T) {
 			"role": "system",
 		})
 
-		userMsg := param.OverrideObj[openai.ChatCompletionUserMessageParam](map[string]interface{}{
+		userMsg := param.Override[openai.ChatCompletionUserMessageParam](map[string]interface{}{
 			"content": []map[string]interface{}{
 				{
 					"type": "text",
@@ -460,9 +476,9 @@ func TestBasicInference(t *testing.T) {
 			Model:    "tensorzero::function_name::json_success",
 			Messages: messages,
 		}
-		req.WithExtraFields(map[string]any{
+		req.SetExtraFields(mergeExtraFields(req.ExtraFields(), map[string]any{
 			"tensorzero::episode_id": episodeID.String(),
-		})
+		}))
 
 		resp, err := client.Chat.Completions.New(ctx, *req)
 		require.NoError(t, err, "API request failed")
@@ -539,7 +555,7 @@ func TestBasicInference(t *testing.T) {
 			Messages: messages,
 		}
 
-		req.WithExtraFields(map[string]any{
+		req.SetExtraFields(mergeExtraFields(req.ExtraFields(), map[string]any{
 			"tensorzero::extra_headers": []map[string]any{
 				{
 					"model_provider_name": "tensorzero::model_name::dummy::echo_extra_info::provider_name::dummy",
@@ -547,7 +563,7 @@ func TestBasicInference(t *testing.T) {
 					"value":               "my-extra-header-value",
 				},
 			},
-		})
+		}))
 
 		resp, err := client.Chat.Completions.New(ctx, *req)
 		require.NoError(t, err, "API request failed")
@@ -589,7 +605,7 @@ func TestBasicInference(t *testing.T) {
 			Model:    "tensorzero::model_name::dummy::echo_extra_info",
 			Messages: messages,
 		}
-		req.WithExtraFields(map[string]any{
+		req.SetExtraFields(mergeExtraFields(req.ExtraFields(), map[string]any{
 			"tensorzero::extra_body": []map[string]any{
 				{
 					"model_provider_name": "tensorzero::model_name::dummy::echo_extra_info::provider_name::dummy",
@@ -600,7 +616,7 @@ func TestBasicInference(t *testing.T) {
 					},
 				},
 			},
-		})
+		}))
 
 		resp, err := client.Chat.Completions.New(ctx, *req)
 		require.NoError(t, err, "API request failed")
@@ -637,7 +653,7 @@ func TestBasicInference(t *testing.T) {
 	t.Run("it should handle json success", func(t *testing.T) {
 		episodeID, _ := uuid.NewV7()
 
-		userMsg := param.OverrideObj[openai.ChatCompletionUserMessageParam](map[string]interface{}{
+		userMsg := param.Override[openai.ChatCompletionUserMessageParam](map[string]interface{}{
 			"content": []map[string]interface{}{
 				{
 					"type": "text",
@@ -657,9 +673,9 @@ func TestBasicInference(t *testing.T) {
 			Model:    "tensorzero::function_name::json_success",
 			Messages: messages,
 		}
-		req.WithExtraFields(map[string]any{
+		req.SetExtraFields(mergeExtraFields(req.ExtraFields(), map[string]any{
 			"tensorzero::episode_id": episodeID.String(),
-		})
+		}))
 
 		resp, err := client.Chat.Completions.New(ctx, *req)
 		require.NoError(t, err, "API request failed")
@@ -689,7 +705,7 @@ func TestBasicInference(t *testing.T) {
 	t.Run("it should handle json invalid system", func(t *testing.T) {
 		episodeID, _ := uuid.NewV7()
 
-		sysMsg := param.OverrideObj[openai.ChatCompletionSystemMessageParam](map[string]interface{}{
+		sysMsg := param.Override[openai.ChatCompletionSystemMessageParam](map[string]interface{}{
 			"content": []map[string]interface{}{
 				{
 					"type": "image_url",
@@ -700,7 +716,7 @@ func TestBasicInference(t *testing.T) {
 			},
 			"role": "system",
 		})
-		userMsg := param.OverrideObj[openai.ChatCompletionUserMessageParam](map[string]interface{}{
+		userMsg := param.Override[openai.ChatCompletionUserMessageParam](map[string]interface{}{
 			"content": []map[string]interface{}{
 				{
 					"type": "text",
@@ -720,9 +736,9 @@ func TestBasicInference(t *testing.T) {
 			Model:    "tensorzero::function_name::json_success",
 			Messages: messages,
 		}
-		req.WithExtraFields(map[string]any{
+		req.SetExtraFields(mergeExtraFields(req.ExtraFields(), map[string]any{
 			"tensorzero::episode_id": episodeID.String(),
-		})
+		}))
 
 		_, err := client.Chat.Completions.New(ctx, *req)
 		require.Error(t, err, "Expected an error for invalid system message")
@@ -743,9 +759,9 @@ func TestBasicInference(t *testing.T) {
 			Model:    "tensorzero::function_name::json_fail",
 			Messages: messages,
 		}
-		req.WithExtraFields(map[string]any{
+		req.SetExtraFields(mergeExtraFields(req.ExtraFields(), map[string]any{
 			"tensorzero::episode_id": episodeID.String(),
-		})
+		}))
 
 		resp, err := client.Chat.Completions.New(ctx, *req)
 		require.NoError(t, err, "API request failed")
@@ -941,17 +957,21 @@ func TestStreamingInference(t *testing.T) {
 		require.Equal(t, int64(10), finalChunk.Usage.PromptTokens)
 		require.Equal(t, int64(16), finalChunk.Usage.CompletionTokens)
 
-		// Simulate waiting for trailing cache write
-		time.Sleep(1 * time.Second)
+		cacheStatus, err := tzopenai.GetCacheStatusChunk(finalChunk)
+		require.NoError(t, err)
+		require.Equal(t, tzopenai.CacheStatusMiss, cacheStatus, "First request should be a cache miss")
 
-		// Second request with cache
-		req.WithExtraFields(map[string]any{
+		// Second request with cache. Canonicalized cache writes are
+		// synchronous with the response, so no trailing-write delay is
+		// needed before the cached request below.
+		req.SetExtraFields(mergeExtraFields(req.ExtraFields(), map[string]any{
 			"tensorzero::episode_id": episodeID.String(),
 			"tensorzero::cache_options": map[string]any{
-				"max_age_s": nil,
-				"enabled":   "on",
+				"max_age_s":    nil,
+				"enabled":      "on",
+				"key_strategy": "canonical",
 			},
-		})
+		}))
 
 		cachedStream := client.Chat.Completions.NewStreaming(ctx, *req)
 		require.NotNil(t, cachedStream, "Cached streaming response should not be nil")
@@ -982,6 +1002,10 @@ func TestStreamingInference(t *testing.T) {
 		require.Equal(t, int64(0), finalCachedChunk.Usage.PromptTokens)
 		require.Equal(t, int64(0), finalCachedChunk.Usage.CompletionTokens)
 		require.Equal(t, int64(0), finalCachedChunk.Usage.TotalTokens)
+
+		cachedStatus, err := tzopenai.GetCacheStatusChunk(finalCachedChunk)
+		require.NoError(t, err)
+		require.Equal(t, tzopenai.CacheStatusHit, cachedStatus, "Second request should be a cache hit")
 	})
 
 	t.Run("it should handle streaming inference with a nonexistent function", func(t *testing.T) {
@@ -1088,7 +1112,7 @@ func TestStreamingInference(t *testing.T) {
 	t.Run("it should handle streaming inference with a missing model", func(t *testing.T) {
 		episodeID, _ := uuid.NewV7()
 
-		sysMsg := param.OverrideObj[openai.ChatCompletionSystemMessageParam](map[string]interface{}{
+		sysMsg := param.Override[openai.ChatCompletionSystemMessageParam](map[string]interface{}{
 			"content": []map[string]interface{}{
 				{
 					"type": "text",
@@ -1128,7 +1152,7 @@ func TestStreamingInference(t *testing.T) {
 	t.Run("it should handle JSON streaming", func(t *testing.T) {
 		episodeID, _ := uuid.NewV7()
 
-		userMsg := param.OverrideObj[openai.ChatCompletionUserMessageParam](map[string]interface{}{
+		userMsg := param.Override[openai.ChatCompletionUserMessageParam](map[string]interface{}{
 			"content": []map[string]interface{}{
 				{
 					"type": "text",
@@ -1152,10 +1176,10 @@ func TestStreamingInference(t *testing.T) {
 				IncludeUsage: openai.Bool(false), // No usage information
 			},
 		}
-		req.WithExtraFields(map[string]any{
+		req.SetExtraFields(mergeExtraFields(req.ExtraFields(), map[string]any{
 			"tensorzero::episode_id":   episodeID.String(),
 			"tensorzero::variant_name": "test-diff-schema",
-		})
+		}))
 
 		// Start streaming
 		stream := client.Chat.Completions.NewStreaming(ctx, *req)
@@ -1297,9 +1321,9 @@ func TestToolCallingInference(t *testing.T) {
 			PresencePenalty: openai.Float(0.5),
 		}
 		addEpisodeIDToRequest(t, req, episodeID)
-		req.WithExtraFields(map[string]any{
+		req.SetExtraFields(mergeExtraFields(req.ExtraFields(), map[string]any{
 			"tensorzero::variant_name": "bad_tool",
-		})
+		}))
 
 		resp, err := client.Chat.Completions.New(ctx, *req)
 		require.NoError(t, err, "API request failed")
@@ -1424,26 +1448,28 @@ func TestToolCallingInference(t *testing.T) {
 			openai.UserMessage("What is the weather like in Tokyo (in Celsius)? Use the provided `get_temperature` tool. Do not say anything else, just call the function."),
 		}
 
-		tools := []openai.ChatCompletionToolParam{
+		tools := []openai.ChatCompletionToolUnionParam{
 			{
-				Function: openai.FunctionDefinitionParam{
-					Name:        "get_temperature",
-					Description: openai.String("Get the current temperature in a given location"),
-					Parameters: openai.FunctionParameters{
-						"type": "object",
-						"properties": map[string]interface{}{
-							"location": map[string]string{
-								"type":        "string",
-								"description": "The location to get the temperature for (e.g. 'New York')",
-							},
-							"units": map[string]interface{}{
-								"type":        "string",
-								"description": "The units to get the temperature in (must be 'fahrenheit' or 'celsius')",
-								"enum":        []string{"fahrenheit", "celsius"},
+				OfFunction: &openai.ChatCompletionFunctionToolParam{
+					Function: openai.FunctionDefinitionParam{
+						Name:        "get_temperature",
+						Description: openai.String("Get the current temperature in a given location"),
+						Parameters: openai.FunctionParameters{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"location": map[string]string{
+									"type":        "string",
+									"description": "The location to get the temperature for (e.g. 'New York')",
+								},
+								"units": map[string]interface{}{
+									"type":        "string",
+									"description": "The units to get the temperature in (must be 'fahrenheit' or 'celsius')",
+									"enum":        []string{"fahrenheit", "celsius"},
+								},
 							},
+							"required":             []string{"location"},
+							"additionalProperties": false,
 						},
-						"required":             []string{"location"},
-						"additionalProperties": false,
 					},
 				},
 			},
@@ -1454,10 +1480,10 @@ func TestToolCallingInference(t *testing.T) {
 			Messages: messages,
 			Tools:    tools,
 		}
-		req.WithExtraFields(map[string]any{
+		req.SetExtraFields(mergeExtraFields(req.ExtraFields(), map[string]any{
 			"tensorzero::episode_id":   episodeID.String(),
 			"tensorzero::variant_name": "openai",
-		})
+		}))
 
 		resp, err := client.Chat.Completions.New(ctx, *req)
 		require.NoError(t, err, "API request failed")
@@ -1503,7 +1529,7 @@ func TestToolCallingInference(t *testing.T) {
 	t.Run("it should reject string input for function with input schema", func(t *testing.T) {
 		episodeID, _ := uuid.NewV7()
 
-		usrMsg := param.OverrideObj[openai.ChatCompletionUserMessageParam](map[string]interface{}{
+		usrMsg := param.Override[openai.ChatCompletionUserMessageParam](map[string]interface{}{
 			"content": []map[string]interface{}{
 				{
 					"type": "text",
@@ -1524,9 +1550,9 @@ func TestToolCallingInference(t *testing.T) {
 			Model:    "tensorzero::function_name::json_success",
 			Messages: messages,
 		}
-		req.WithExtraFields(map[string]any{
+		req.SetExtraFields(mergeExtraFields(req.ExtraFields(), map[string]any{
 			"tensorzero::episode_id": episodeID.String(),
-		})
+		}))
 
 		_, err := client.Chat.Completions.New(ctx, *req)
 		require.Error(t, err, "Expected an error for invalid input schema")
@@ -1552,9 +1578,9 @@ func TestToolCallingInference(t *testing.T) {
 			ParallelToolCalls: openai.Bool(true),
 		}
 		addEpisodeIDToRequest(t, req, episodeID)
-		req.WithExtraFields(map[string]any{
+		req.SetExtraFields(mergeExtraFields(req.ExtraFields(), map[string]any{
 			"tensorzero::variant_name": "openai",
-		})
+		}))
 
 		// Initial request
 		resp, err := client.Chat.Completions.New(ctx, *req)
@@ -1582,9 +1608,9 @@ func TestToolCallingInference(t *testing.T) {
 			Messages: messages,
 		}
 		addEpisodeIDToRequest(t, finalReq, episodeID)
-		finalReq.WithExtraFields(map[string]any{
+		finalReq.SetExtraFields(mergeExtraFields(finalReq.ExtraFields(), map[string]any{
 			"tensorzero::variant_name": "openai",
-		})
+		}))
 
 		// mullti-turn/final request
 		finalResp, err := client.Chat.Completions.New(ctx, *finalReq)
@@ -0,0 +1,76 @@
+/**
+Tests for the OpenAI-style rate-limit response headers
+(x-ratelimit-limit-requests, x-ratelimit-limit-tokens,
+x-ratelimit-remaining-requests, x-ratelimit-remaining-tokens,
+x-ratelimit-reset-requests, x-ratelimit-reset-tokens) that
+/openai/v1/chat/completions emits so downstream libraries (LangChain,
+LiteLLM, autoscalers) can key retry/backoff logic off them the same way
+they do for the real OpenAI API.
+*/
+
+package tests
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var rateLimitHeaders = []string{
+	"x-ratelimit-limit-requests",
+	"x-ratelimit-limit-tokens",
+	"x-ratelimit-remaining-requests",
+	"x-ratelimit-remaining-tokens",
+	"x-ratelimit-reset-requests",
+	"x-ratelimit-reset-tokens",
+}
+
+func TestRateLimitHeaders(t *testing.T) {
+	t.Run("should emit and decrease rate-limit headers across requests", func(t *testing.T) {
+		var headers []http.Header
+
+		rlClient := openai.NewClient(
+			option.WithBaseURL("http://127.0.0.1:3000/openai/v1"),
+			option.WithAPIKey("donotuse"),
+			option.WithMiddleware(func(req *http.Request, next option.MiddlewareNext) (*http.Response, error) {
+				resp, err := next(req)
+				if resp != nil {
+					headers = append(headers, resp.Header.Clone())
+				}
+				return resp, err
+			}),
+		)
+
+		messages := []openai.ChatCompletionMessageParamUnion{
+			openai.UserMessage("Hello"),
+		}
+		req := openai.ChatCompletionNewParams{
+			Model:    "tensorzero::function_name::basic_test",
+			Messages: messages,
+		}
+
+		for i := 0; i < 2; i++ {
+			_, err := rlClient.Chat.Completions.New(ctx, req)
+			require.NoError(t, err, "API request failed")
+		}
+		require.Len(t, headers, 2)
+
+		for _, name := range rateLimitHeaders {
+			for _, h := range headers {
+				assert.NotEmpty(t, h.Get(name), "missing rate-limit header %s", name)
+			}
+		}
+
+		firstRemaining, err := strconv.Atoi(headers[0].Get("x-ratelimit-remaining-requests"))
+		require.NoError(t, err)
+		secondRemaining, err := strconv.Atoi(headers[1].Get("x-ratelimit-remaining-requests"))
+		require.NoError(t, err)
+		assert.Less(t, secondRemaining, firstRemaining,
+			"x-ratelimit-remaining-requests should decrease across back-to-back calls")
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/openai/openai-go/v3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"tensorzero/client/go/tzopenai"
 )
 
 func TestRawUsage(t *testing.T) {
@@ -160,4 +161,36 @@ func TestRawUsage(t *testing.T) {
 
 		assert.True(t, foundRawUsage, "Streaming response should include tensorzero_raw_usage in final chunk")
 	})
+
+	t.Run("should normalize raw usage across providers", func(t *testing.T) {
+		for _, model := range []string{
+			"tensorzero::model_name::gpt-4o-mini-2024-07-18",
+			"tensorzero::model_name::claude-3-haiku-20240307",
+			"tensorzero::model_name::gemini-1.5-flash",
+		} {
+			t.Run(model, func(t *testing.T) {
+				episodeID, _ := uuid.NewV7()
+
+				req := &openai.ChatCompletionNewParams{
+					Model: model,
+					Messages: []openai.ChatCompletionMessageParamUnion{
+						openai.UserMessage("Hello"),
+					},
+				}
+				tzopenai.TensorZeroOptions{EpisodeID: episodeID, IncludeRawUsage: true}.Apply(req)
+
+				resp, err := client.Chat.Completions.New(ctx, *req)
+				require.NoError(t, err, "API request failed")
+
+				entries, err := tzopenai.RawUsage(resp)
+				require.NoError(t, err)
+				require.NotEmpty(t, entries, "tensorzero_raw_usage should have at least one entry")
+
+				normalized, err := entries[0].NormalizedUsage()
+				require.NoError(t, err)
+				assert.Greater(t, normalized.InputTokens, int64(0), "normalized usage should report input tokens")
+				assert.Greater(t, normalized.OutputTokens, int64(0), "normalized usage should report output tokens")
+			})
+		}
+	})
 }
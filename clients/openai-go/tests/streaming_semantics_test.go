@@ -0,0 +1,113 @@
+/**
+Tests for OpenAI SSE streaming contract details that TestStreamingInference
+doesn't exercise: episode-id propagation on the first chunk, tool-call
+argument deltas concatenating into valid JSON, a clean stream end (the
+SDK consumes the `[DONE]` sentinel internally, surfacing it as Next()
+returning false with no error), and the stream_options.include_usage
+contract of a terminal usage-only chunk with empty choices.
+*/
+
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/openai/openai-go/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamingSemantics(t *testing.T) {
+	t.Run("should surface episode_id on the first chunk", func(t *testing.T) {
+		episodeID, _ := uuid.NewV7()
+
+		req := openai.ChatCompletionNewParams{
+			Model: "tensorzero::function_name::basic_test",
+			Messages: []openai.ChatCompletionMessageParamUnion{
+				openai.UserMessage("Hello"),
+			},
+		}
+		req.SetExtraFields(map[string]any{
+			"tensorzero::episode_id": episodeID.String(),
+		})
+
+		stream := client.Chat.Completions.NewStreaming(ctx, req)
+		require.True(t, stream.Next(), "Expected at least one chunk")
+
+		firstChunk := stream.Current()
+		extra, ok := firstChunk.JSON.ExtraFields["episode_id"]
+		require.True(t, ok, "First chunk should carry episode_id")
+		var responseEpisodeID string
+		require.NoError(t, json.Unmarshal([]byte(extra.Raw()), &responseEpisodeID))
+		assert.Equal(t, episodeID.String(), responseEpisodeID)
+
+		for stream.Next() {
+		}
+		require.NoError(t, stream.Err(), "Stream should end cleanly after the [DONE] sentinel")
+	})
+
+	t.Run("should stream tool-call argument deltas that concatenate into valid JSON", func(t *testing.T) {
+		req := openai.ChatCompletionNewParams{
+			Model: "tensorzero::function_name::weather_helper",
+			Messages: []openai.ChatCompletionMessageParamUnion{
+				openai.UserMessage("What's the weather in Brooklyn?"),
+			},
+		}
+
+		stream := client.Chat.Completions.NewStreaming(ctx, req)
+		require.NotNil(t, stream, "Streaming response should not be nil")
+
+		var arguments string
+		for stream.Next() {
+			chunk := stream.Current()
+			if len(chunk.Choices) == 0 {
+				continue
+			}
+			for _, call := range chunk.Choices[0].Delta.ToolCalls {
+				arguments += call.Function.Arguments
+			}
+		}
+		require.NoError(t, stream.Err(), "Stream encountered an error")
+
+		require.NotEmpty(t, arguments, "Expected at least one tool-call argument delta")
+		var parsed map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(arguments), &parsed),
+			"Concatenated tool-call argument deltas should form valid JSON")
+	})
+
+	t.Run("should emit a terminal usage chunk with empty choices when include_usage is set", func(t *testing.T) {
+		req := openai.ChatCompletionNewParams{
+			Model: "tensorzero::function_name::basic_test",
+			Messages: []openai.ChatCompletionMessageParamUnion{
+				openai.UserMessage("Hello"),
+			},
+			StreamOptions: openai.ChatCompletionStreamOptionsParam{
+				IncludeUsage: openai.Bool(true),
+			},
+		}
+
+		stream := client.Chat.Completions.NewStreaming(ctx, req)
+		require.NotNil(t, stream, "Streaming response should not be nil")
+
+		var lastContentChunkSeen bool
+		var allChunks []openai.ChatCompletionChunk
+		for stream.Next() {
+			allChunks = append(allChunks, stream.Current())
+		}
+		require.NoError(t, stream.Err(), "Stream encountered an error")
+		require.NotEmpty(t, allChunks)
+
+		finalChunk := allChunks[len(allChunks)-1]
+		assert.Empty(t, finalChunk.Choices, "Terminal usage chunk should have empty choices")
+		assert.Greater(t, finalChunk.Usage.TotalTokens, int64(0), "Terminal chunk should carry populated usage")
+
+		for _, chunk := range allChunks[:len(allChunks)-1] {
+			if len(chunk.Choices) > 0 && chunk.Choices[0].Delta.Content != "" {
+				lastContentChunkSeen = true
+			}
+		}
+		assert.True(t, lastContentChunkSeen, "Expected at least one content chunk before the terminal usage chunk")
+	})
+}
@@ -0,0 +1,50 @@
+/**
+Tests for tensorzero::tool_stream_options, which asks the gateway to emit
+progressive tool_call.partial / tool_call.validated / tool_call.error SSE
+events alongside the standard OpenAI-compatible tool-call deltas that
+TestToolCallingInference already covers.
+*/
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tensorzero/client/go/tzopenai"
+	"tensorzero/client/go/tzstream"
+)
+
+func TestToolStreamOptions(t *testing.T) {
+	t.Run("should emit progressive partial and validated tool-call events", func(t *testing.T) {
+		req := &openai.ChatCompletionNewParams{
+			Model: "tensorzero::function_name::weather_helper",
+			Messages: []openai.ChatCompletionMessageParamUnion{
+				openai.UserMessage("Hi I'm visiting Brooklyn from Brazil. What's the weather?"),
+			},
+		}
+		tzopenai.TensorZeroOptions{ToolStreamOptions: true}.Apply(req)
+
+		stream := client.Chat.Completions.NewStreaming(ctx, *req)
+		require.NotNil(t, stream, "Streaming response should not be nil")
+
+		it := tzstream.New(stream)
+
+		var sawPartial, sawValidated bool
+		for it.Next() {
+			switch event := it.Event().(type) {
+			case tzstream.ToolCallPartialEvent:
+				sawPartial = true
+				assert.Equal(t, "get_temperature", event.Name)
+			case tzstream.ToolCallValidatedEvent:
+				sawValidated = true
+				assert.Equal(t, "Brooklyn", event.Arguments["location"])
+			}
+		}
+		require.NoError(t, it.Err(), "Stream encountered an error")
+		assert.True(t, sawPartial, "Should have seen at least one progressive tool_call.partial event")
+		assert.True(t, sawValidated, "Should have seen a terminal tool_call.validated event")
+	})
+}
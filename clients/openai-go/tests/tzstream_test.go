@@ -0,0 +1,72 @@
+/**
+Tests for tzstream.Iterator, the typed streaming event wrapper. These
+cover the same round-trip scenario as TestExtraContent's streaming case,
+but via the typed iterator instead of hand-parsing
+delta.JSON.ExtraFields["tensorzero_extra_content"].
+*/
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/openai/openai-go/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tensorzero/client/go/tzstream"
+)
+
+func TestTypedStream(t *testing.T) {
+	t.Run("should decode typed events and collect a replayable message", func(t *testing.T) {
+		episodeID, _ := uuid.NewV7()
+
+		req := &openai.ChatCompletionNewParams{
+			Model: "tensorzero::model_name::dummy::reasoner",
+			Messages: []openai.ChatCompletionMessageParamUnion{
+				openai.UserMessage("Hello"),
+			},
+		}
+		req.SetExtraFields(map[string]any{
+			"tensorzero::episode_id": episodeID.String(),
+		})
+
+		stream := client.Chat.Completions.NewStreaming(ctx, *req)
+		require.NotNil(t, stream, "Streaming response should not be nil")
+
+		it := tzstream.New(stream)
+
+		var sawThought, sawText, sawDone bool
+		for it.Next() {
+			switch it.Event().(type) {
+			case tzstream.ThoughtDelta:
+				sawThought = true
+			case tzstream.TextDelta:
+				sawText = true
+			case tzstream.Done:
+				sawDone = true
+			}
+		}
+		require.NoError(t, it.Err(), "Stream encountered an error")
+		assert.True(t, sawThought, "Should have seen at least one ThoughtDelta")
+		assert.True(t, sawText, "Should have seen at least one TextDelta")
+		assert.True(t, sawDone, "Should have seen a Done event")
+
+		// Replay the collected message on a follow-up request.
+		roundtripReq := &openai.ChatCompletionNewParams{
+			Model: "tensorzero::model_name::dummy::echo",
+			Messages: []openai.ChatCompletionMessageParamUnion{
+				openai.UserMessage("Hello"),
+				it.Collect(),
+				openai.UserMessage("Continue"),
+			},
+		}
+		roundtripReq.SetExtraFields(map[string]any{
+			"tensorzero::episode_id": episodeID.String(),
+		})
+
+		roundtripResp, err := client.Chat.Completions.New(ctx, *roundtripReq)
+		require.NoError(t, err, "Round-trip API request failed")
+		require.NotEmpty(t, roundtripResp.Choices, "Round-trip response should have choices")
+	})
+}
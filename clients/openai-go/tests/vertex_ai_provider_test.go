@@ -0,0 +1,43 @@
+/**
+Tests for the tensorzero::model_name::vertex_ai::gemini-... short-hand,
+analogous to tensorzero::model_name::openai::gpt-4o-mini in
+TestImageInference, so a user can swap a model's provider to Vertex AI
+without touching the function/variant config. Exercises the usage-token
+translation (usageMetadata -> Usage) the gateway's Vertex AI provider is
+responsible for; basic inference and tool-call mapping against the plain
+gemini-1.5-flash model/function are already covered by
+vertex_ai_test.go, so this file sticks to what's specific to the
+vertex_ai:: short-hand.
+*/
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVertexAIProvider(t *testing.T) {
+	t.Run("should translate usageMetadata into the standard Usage struct", func(t *testing.T) {
+		usrMsg := openai.UserMessage([]openai.ChatCompletionContentPartUnionParam{
+			openai.ImageContentPart(openai.ChatCompletionContentPartImageImageURLParam{
+				URL: "https://raw.githubusercontent.com/tensorzero/tensorzero/ff3e17bbd3e32f483b027cf81b54404788c90dc1/tensorzero-internal/tests/e2e/providers/ferris.png",
+			}),
+			openai.TextContentPart("Output exactly two words describing the image"),
+		})
+
+		req := &openai.ChatCompletionNewParams{
+			Model:    "tensorzero::model_name::vertex_ai::gemini-1.5-flash",
+			Messages: []openai.ChatCompletionMessageParamUnion{usrMsg},
+		}
+
+		resp, err := client.Chat.Completions.New(ctx, *req)
+		require.NoError(t, err, "API request failed")
+		assert.NotEmpty(t, resp.Choices[0].Message.Content)
+		assert.Greater(t, resp.Usage.PromptTokens, int64(100))
+		assert.Greater(t, resp.Usage.CompletionTokens, int64(0))
+	})
+}
@@ -0,0 +1,130 @@
+/**
+Integration tests for the vertex_ai / google_ai_studio Gemini provider,
+parallel to TestStreamingInference and TestToolCallingInference but
+targeting a Gemini-backed model/function so the OpenAI-compatible
+response shapes (message content, tool calls, FinishReason, and the
+streamed terminal usage chunk) are verified against a non-OpenAI-shaped
+backend too. Also covers multimodal content
+parts (image + text in one user message), since Gemini's content-part
+handling differs enough from the OpenAI providers already under test to
+warrant its own assertion rather than assuming the shared param types
+behave the same way end to end.
+*/
+
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVertexAIGemini(t *testing.T) {
+	t.Run("should handle basic inference", func(t *testing.T) {
+		messages := []openai.ChatCompletionMessageParamUnion{
+			{OfSystem: systemMessageWithAssistant(t, "Alfred Pennyworth")},
+			openai.UserMessage("Hello"),
+		}
+
+		req := &openai.ChatCompletionNewParams{
+			Model:    "tensorzero::model_name::gemini-1.5-flash",
+			Messages: messages,
+		}
+
+		resp, err := client.Chat.Completions.New(ctx, *req)
+		require.NoError(t, err, "API request failed")
+		assert.NotEmpty(t, resp.Choices[0].Message.Content)
+		assert.Equal(t, "stop", resp.Choices[0].FinishReason)
+	})
+
+	t.Run("should stream inference", func(t *testing.T) {
+		messages := []openai.ChatCompletionMessageParamUnion{
+			openai.UserMessage("Hello"),
+		}
+
+		req := &openai.ChatCompletionNewParams{
+			Model:    "tensorzero::model_name::gemini-1.5-flash",
+			Messages: messages,
+			StreamOptions: openai.ChatCompletionStreamOptionsParam{
+				IncludeUsage: openai.Bool(true),
+			},
+		}
+
+		stream := client.Chat.Completions.NewStreaming(ctx, *req)
+		require.NotNil(t, stream, "Streaming response should not be nil")
+
+		var allChunks []openai.ChatCompletionChunk
+		for stream.Next() {
+			allChunks = append(allChunks, stream.Current())
+		}
+		require.NoError(t, stream.Err(), "Stream encountered an error")
+		require.NotEmpty(t, allChunks, "No chunks were received")
+
+		finalChunk := allChunks[len(allChunks)-1]
+		assert.Greater(t, finalChunk.Usage.TotalTokens, int64(0))
+	})
+
+	t.Run("should handle tool-calling inference", func(t *testing.T) {
+		messages := []openai.ChatCompletionMessageParamUnion{
+			openai.UserMessage("Hi I'm visiting Brooklyn from Brazil. What's the weather?"),
+		}
+
+		req := &openai.ChatCompletionNewParams{
+			Model:    "tensorzero::function_name::weather_helper",
+			Messages: messages,
+		}
+		req.SetExtraFields(map[string]any{
+			"tensorzero::variant_name": "gemini",
+		})
+
+		resp, err := client.Chat.Completions.New(ctx, *req)
+		require.NoError(t, err, "API request failed")
+
+		require.NotNil(t, resp.Choices[0].Message.ToolCalls, "Tool calls should not be nil")
+		assert.Equal(t, "tool_calls", resp.Choices[0].FinishReason)
+	})
+
+	t.Run("should handle multimodal content parts", func(t *testing.T) {
+		messages := []openai.ChatCompletionMessageParamUnion{
+			openai.UserMessage([]openai.ChatCompletionContentPartUnionParam{
+				openai.ImageContentPart(openai.ChatCompletionContentPartImageImageURLParam{
+					URL: "https://raw.githubusercontent.com/tensorzero/tensorzero/ff3e17bbd3e32f483b027cf81b54404788c90dc1/tensorzero-internal/tests/e2e/providers/ferris.png",
+				}),
+				openai.TextContentPart("Output exactly two words describing the image"),
+			}),
+		}
+
+		req := &openai.ChatCompletionNewParams{
+			Model:    "tensorzero::model_name::gemini-1.5-flash",
+			Messages: messages,
+		}
+
+		resp, err := client.Chat.Completions.New(ctx, *req)
+		require.NoError(t, err, "API request failed")
+		assert.NotEmpty(t, resp.Choices[0].Message.Content)
+	})
+
+	t.Run("should honor responseMimeType for JSON functions", func(t *testing.T) {
+		messages := []openai.ChatCompletionMessageParamUnion{
+			{OfSystem: systemMessageWithAssistant(t, "Alfred Pennyworth")},
+			openai.UserMessage("Extract the country: Japan"),
+		}
+
+		req := &openai.ChatCompletionNewParams{
+			Model:    "tensorzero::function_name::json_success",
+			Messages: messages,
+		}
+		req.SetExtraFields(map[string]any{
+			"tensorzero::variant_name": "gemini",
+		})
+
+		resp, err := client.Chat.Completions.New(ctx, *req)
+		require.NoError(t, err, "API request failed")
+
+		var content map[string]any
+		require.NoError(t, json.Unmarshal([]byte(resp.Choices[0].Message.Content), &content))
+	})
+}
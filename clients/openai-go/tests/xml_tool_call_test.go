@@ -0,0 +1,62 @@
+/**
+Tests for the xml_inline tool_call_format variant, which lets a
+tool-calling function run against models that emit tool calls as inline
+`<function_calls><invoke>...</invoke></function_calls>` XML instead of
+native tool_call JSON. `Chat.Completions.New` callers should still see
+resp.Choices[0].Message.ToolCalls populated identically to the JSON-native
+case covered by TestToolCallingInference.
+*/
+
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tensorzero/client/go/tzopenai"
+)
+
+func TestXMLInlineToolCalls(t *testing.T) {
+	t.Run("should parse a single inline invoke block into a standard tool call", func(t *testing.T) {
+		req := &openai.ChatCompletionNewParams{
+			Model: "tensorzero::function_name::weather_helper",
+			Messages: []openai.ChatCompletionMessageParamUnion{
+				openai.UserMessage("Hi I'm visiting Brooklyn from Brazil. What's the weather?"),
+			},
+		}
+		tzopenai.TensorZeroOptions{VariantName: "xml_tool_call"}.Apply(req)
+
+		resp, err := client.Chat.Completions.New(ctx, *req)
+		require.NoError(t, err, "API request failed")
+
+		require.NotNil(t, resp.Choices[0].Message.ToolCalls, "Tool calls should not be nil")
+		toolCalls := resp.Choices[0].Message.ToolCalls
+		require.Len(t, toolCalls, 1, "There should be exactly one tool call")
+		assert.Equal(t, "get_temperature", toolCalls[0].Function.Name)
+
+		var args map[string]any
+		require.NoError(t, json.Unmarshal([]byte(toolCalls[0].Function.Arguments), &args))
+		assert.Equal(t, "Brooklyn", args["location"])
+		assert.Equal(t, "tool_calls", resp.Choices[0].FinishReason)
+	})
+
+	t.Run("should surface parse warnings for malformed XML instead of failing the request", func(t *testing.T) {
+		req := &openai.ChatCompletionNewParams{
+			Model: "tensorzero::function_name::weather_helper",
+			Messages: []openai.ChatCompletionMessageParamUnion{
+				openai.UserMessage("Trigger a malformed invoke block"),
+			},
+		}
+		tzopenai.TensorZeroOptions{VariantName: "xml_tool_call_malformed"}.Apply(req)
+
+		resp, err := client.Chat.Completions.New(ctx, *req)
+		require.NoError(t, err, "Malformed XML should still produce a best-effort response")
+
+		warnings, err := tzopenai.ParseWarnings(resp)
+		require.NoError(t, err)
+		assert.NotEmpty(t, warnings, "Malformed inline XML should surface tensorzero::parse_warnings")
+	})
+}
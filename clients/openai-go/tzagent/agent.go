@@ -0,0 +1,188 @@
+// Package tzagent implements a client-side multi-step tool-execution
+// loop: register Go handlers for a function's tools, then Run drives the
+// request / tool-call / tool-result loop under a single episode until
+// the model returns a non-tool response or the iteration cap is hit,
+// eliminating the manual loop otherwise required around
+// client.Chat.Completions.New. This is a pure client-side convenience -
+// the gateway has no server-assisted tool-execution feature to delegate
+// to, so every iteration is a full round trip through Run/RunStreaming.
+package tzagent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"tensorzero/client/go/tzstream"
+
+	"github.com/openai/openai-go/v3"
+)
+
+// defaultMaxIterations is the tool-execution iteration cap used when
+// none is set via MaxIterations.
+const defaultMaxIterations = 10
+
+// ToolHandler executes one tool call's arguments and returns the result
+// to append as a role=tool message. A returned error is not fatal to the
+// run: it's encoded as a tool-message error payload so the model can see
+// the failure and recover.
+type ToolHandler func(args map[string]any) (string, error)
+
+// Agent drives the client-side multi-step tool-execution loop for a
+// single openai.Client; the gateway does not execute tools itself.
+type Agent struct {
+	client        openai.Client
+	tools         map[string]ToolHandler
+	maxIterations int
+}
+
+// New returns an Agent bound to client, with a default iteration cap of
+// 10 tool-execution turns.
+func New(client openai.Client) *Agent {
+	return &Agent{client: client, tools: map[string]ToolHandler{}, maxIterations: defaultMaxIterations}
+}
+
+// RegisterTool registers handler as the implementation of the named
+// tool. A tool call for a name without a registered handler is reported
+// to the model as a tool message carrying an "unknown tool" error
+// payload, rather than failing the run.
+func (a *Agent) RegisterTool(name string, handler ToolHandler) {
+	a.tools[name] = handler
+}
+
+// MaxIterations overrides the default tool-execution iteration cap.
+func (a *Agent) MaxIterations(n int) {
+	a.maxIterations = n
+}
+
+// Run repeatedly calls client.Chat.Completions.New: each time the
+// response's finish_reason is "tool_calls", it runs the matching
+// registered handlers one after another, in the order of the turn's tool
+// calls (they are not run concurrently, even when parallel_tool_calls is
+// set), appends the assistant and tool-result messages, and
+// re-invokes the variant under the same episode. It returns the first
+// non-tool-calls response, or an error if the iteration cap is hit
+// first.
+func (a *Agent) Run(ctx context.Context, req openai.ChatCompletionNewParams) (*openai.ChatCompletion, error) {
+	for i := 0; i < a.maxIterations; i++ {
+		resp, err := a.client.Chat.Completions.New(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("tzagent: chat completion: %w", err)
+		}
+		if resp.Choices[0].FinishReason != "tool_calls" {
+			return resp, nil
+		}
+
+		assistantMessage := resp.Choices[0].Message
+		req.Messages = append(req.Messages, assistantMessage.ToParam())
+		for _, toolCall := range assistantMessage.ToolCalls {
+			req.Messages = append(req.Messages, openai.ToolMessage(a.executeToolCall(toolCall), toolCall.ID))
+		}
+	}
+	return nil, fmt.Errorf("tzagent: exceeded max tool iterations (%d)", a.maxIterations)
+}
+
+// RunStreaming behaves like Run, but drives every turn (including
+// intermediate tool-calling ones) as a stream and invokes onEvent for
+// every tzstream.StreamEvent produced, so a caller can render
+// interleaved tool_calls and content deltas live instead of only seeing
+// the final turn. It returns once a turn's Done event reports a
+// finish reason other than "tool_calls", or an error if the iteration
+// cap is hit first.
+func (a *Agent) RunStreaming(ctx context.Context, req openai.ChatCompletionNewParams, onEvent func(tzstream.StreamEvent)) error {
+	for i := 0; i < a.maxIterations; i++ {
+		it := tzstream.New(a.client.Chat.Completions.NewStreaming(ctx, req))
+
+		toolCalls := map[int]*partialToolCall{}
+		var finishReason string
+		for it.Next() {
+			event := it.Event()
+			onEvent(event)
+			switch e := event.(type) {
+			case tzstream.ToolCallDelta:
+				call := toolCalls[e.Index]
+				if call == nil {
+					call = &partialToolCall{}
+					toolCalls[e.Index] = call
+				}
+				if e.ID != "" {
+					call.id = e.ID
+				}
+				if e.Name != "" {
+					call.name = e.Name
+				}
+				call.arguments += e.ArgumentsChunk
+			case tzstream.Done:
+				finishReason = e.FinishReason
+			}
+		}
+		if err := it.Err(); err != nil {
+			return fmt.Errorf("tzagent: chat completion: %w", err)
+		}
+		if finishReason != "tool_calls" {
+			return nil
+		}
+
+		assistantMessage := it.Collect()
+		for _, index := range sortedIndices(toolCalls) {
+			call := toolCalls[index]
+			assistantMessage.OfAssistant.ToolCalls = append(assistantMessage.OfAssistant.ToolCalls, openai.ChatCompletionMessageToolCallUnionParam{
+				OfFunction: &openai.ChatCompletionMessageFunctionToolCallParam{
+					ID:       call.id,
+					Function: openai.ChatCompletionMessageFunctionToolCallFunctionParam{Name: call.name, Arguments: call.arguments},
+				},
+			})
+		}
+		req.Messages = append(req.Messages, assistantMessage)
+		for _, index := range sortedIndices(toolCalls) {
+			call := toolCalls[index]
+			req.Messages = append(req.Messages, openai.ToolMessage(a.executeToolCallArgs(call.name, call.arguments), call.id))
+		}
+	}
+	return fmt.Errorf("tzagent: exceeded max tool iterations (%d)", a.maxIterations)
+}
+
+// partialToolCall accumulates one streamed tool call's id/name/arguments
+// across ToolCallDelta events sharing its index.
+type partialToolCall struct {
+	id        string
+	name      string
+	arguments string
+}
+
+func sortedIndices(toolCalls map[int]*partialToolCall) []int {
+	indices := make([]int, 0, len(toolCalls))
+	for index := range toolCalls {
+		indices = append(indices, index)
+	}
+	sort.Ints(indices)
+	return indices
+}
+
+func (a *Agent) executeToolCall(toolCall openai.ChatCompletionMessageToolCallUnion) string {
+	return a.executeToolCallArgs(toolCall.Function.Name, toolCall.Function.Arguments)
+}
+
+func (a *Agent) executeToolCallArgs(name, arguments string) string {
+	handler, ok := a.tools[name]
+	if !ok {
+		return encodeToolError(fmt.Sprintf("unknown tool %q", name))
+	}
+
+	var args map[string]any
+	if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+		return encodeToolError(fmt.Sprintf("invalid tool arguments: %v", err))
+	}
+
+	result, err := handler(args)
+	if err != nil {
+		return encodeToolError(err.Error())
+	}
+	return result
+}
+
+func encodeToolError(message string) string {
+	payload, _ := json.Marshal(map[string]string{"error": message})
+	return string(payload)
+}
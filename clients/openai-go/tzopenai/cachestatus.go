@@ -0,0 +1,49 @@
+package tzopenai
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/packages/respjson"
+)
+
+// CacheStatus is the value of the "tensorzero::cache_status" response
+// extra field.
+type CacheStatus string
+
+const (
+	CacheStatusMiss  CacheStatus = "miss"
+	CacheStatusHit   CacheStatus = "hit"
+	CacheStatusStale CacheStatus = "stale"
+)
+
+// GetCacheStatus parses the "tensorzero::cache_status" field off a chat
+// completion response. It returns "" (and no error) when the field is
+// absent, e.g. because the request had no tensorzero::cache_options set.
+func GetCacheStatus(resp *openai.ChatCompletion) (CacheStatus, error) {
+	if resp == nil {
+		return "", nil
+	}
+	return decodeCacheStatus(resp.JSON.ExtraFields)
+}
+
+// GetCacheStatusChunk parses the "tensorzero::cache_status" field off a
+// single streaming chunk. As with GetCacheStatus, this is only populated
+// for requests that set tensorzero::cache_options; it's carried on the
+// terminal usage-only chunk.
+func GetCacheStatusChunk(chunk openai.ChatCompletionChunk) (CacheStatus, error) {
+	return decodeCacheStatus(chunk.JSON.ExtraFields)
+}
+
+func decodeCacheStatus(extraFields map[string]respjson.Field) (CacheStatus, error) {
+	field, ok := extraFields["tensorzero::cache_status"]
+	if !ok {
+		return "", nil
+	}
+	var status CacheStatus
+	if err := json.Unmarshal([]byte(field.Raw()), &status); err != nil {
+		return "", fmt.Errorf("tzopenai: parsing tensorzero::cache_status: %w", err)
+	}
+	return status, nil
+}
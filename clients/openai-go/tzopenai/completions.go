@@ -0,0 +1,44 @@
+package tzopenai
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/openai/openai-go/v3"
+)
+
+// CompletionRawUsage parses the "tensorzero_raw_usage" field off a legacy
+// /v1/completions response's usage object, the same way RawUsage does for
+// chat completions.
+func CompletionRawUsage(resp *openai.Completion) ([]RawUsageEntry, error) {
+	if resp == nil {
+		return nil, nil
+	}
+	field, ok := resp.Usage.JSON.ExtraFields["tensorzero_raw_usage"]
+	if !ok {
+		return nil, nil
+	}
+	var entries []RawUsageEntry
+	if err := json.Unmarshal([]byte(field.Raw()), &entries); err != nil {
+		return nil, fmt.Errorf("tzopenai: parsing tensorzero_raw_usage: %w", err)
+	}
+	return entries, nil
+}
+
+// CompletionRawResponse parses the "tensorzero_raw_response" field off a
+// legacy /v1/completions response, the same way RawResponse does for chat
+// completions.
+func CompletionRawResponse(resp *openai.Completion) ([]RawResponseEntry, error) {
+	if resp == nil {
+		return nil, nil
+	}
+	field, ok := resp.JSON.ExtraFields["tensorzero_raw_response"]
+	if !ok {
+		return nil, nil
+	}
+	var entries []RawResponseEntry
+	if err := json.Unmarshal([]byte(field.Raw()), &entries); err != nil {
+		return nil, fmt.Errorf("tzopenai: parsing tensorzero_raw_response: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,127 @@
+package tzopenai
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/openai/openai-go/v3"
+)
+
+// ContentBlock is one block of the "tensorzero_extra_content" array that
+// TensorZero attaches to assistant messages for content that has no
+// standard OpenAI content-part representation, such as a model's
+// chain-of-thought.
+type ContentBlock interface {
+	// InsertIndex reports the position, among the assistant message's
+	// content blocks, where this block should be reinserted on replay.
+	InsertIndex() int
+
+	isContentBlock()
+}
+
+// ThoughtBlock is a model's reasoning/chain-of-thought content.
+type ThoughtBlock struct {
+	Index     int    `json:"insert_index"`
+	Text      string `json:"text"`
+	Signature string `json:"signature,omitempty"`
+}
+
+func (b ThoughtBlock) InsertIndex() int { return b.Index }
+func (ThoughtBlock) isContentBlock()    {}
+
+// MarshalJSON emits the block with its "type": "thought" tag, so a replayed
+// block is recognized as a thought again (see decodeContentBlock).
+func (b ThoughtBlock) MarshalJSON() ([]byte, error) {
+	type thoughtBlock ThoughtBlock
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		thoughtBlock
+	}{Type: "thought", thoughtBlock: thoughtBlock(b)})
+}
+
+// UnknownBlock is a "tensorzero_extra_content" block whose "type" this
+// package doesn't have a typed representation for. Raw holds the
+// untouched JSON so it can still be round-tripped on a follow-up request.
+type UnknownBlock struct {
+	Index int             `json:"insert_index"`
+	Type  string          `json:"type"`
+	Raw   json.RawMessage `json:"-"`
+}
+
+func (b UnknownBlock) InsertIndex() int { return b.Index }
+func (UnknownBlock) isContentBlock()    {}
+
+// MarshalJSON re-emits the original block verbatim so that round-tripping
+// an UnknownBlock back to the API doesn't lose provider-specific fields.
+func (b UnknownBlock) MarshalJSON() ([]byte, error) {
+	return b.Raw, nil
+}
+
+func decodeContentBlock(raw json.RawMessage) (ContentBlock, error) {
+	var head struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(raw, &head); err != nil {
+		return nil, err
+	}
+	if head.Type == "thought" {
+		var block ThoughtBlock
+		if err := json.Unmarshal(raw, &block); err != nil {
+			return nil, err
+		}
+		return block, nil
+	}
+	var block UnknownBlock
+	if err := json.Unmarshal(raw, &block); err != nil {
+		return nil, err
+	}
+	block.Raw = raw
+	return block, nil
+}
+
+// ExtraContent parses the "tensorzero_extra_content" field attached to an
+// assistant message, returning the Thought/Unknown blocks TensorZero
+// inserted alongside the message's standard OpenAI content. It returns a
+// nil slice (and no error) when the message carries no extra content.
+func ExtraContent(message openai.ChatCompletionMessage) ([]ContentBlock, error) {
+	field, ok := message.JSON.ExtraFields["tensorzero_extra_content"]
+	if !ok {
+		return nil, nil
+	}
+	var raws []json.RawMessage
+	if err := json.Unmarshal([]byte(field.Raw()), &raws); err != nil {
+		return nil, fmt.Errorf("tzopenai: parsing tensorzero_extra_content: %w", err)
+	}
+	blocks := make([]ContentBlock, 0, len(raws))
+	for _, raw := range raws {
+		block, err := decodeContentBlock(raw)
+		if err != nil {
+			return nil, fmt.Errorf("tzopenai: parsing tensorzero_extra_content block: %w", err)
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+// SetExtraContent attaches blocks to an assistant message param so it can
+// be replayed on a follow-up request, in place of the manual
+// assistantMsg.SetExtraFields(map[string]any{"tensorzero_extra_content": ...})
+// dance.
+func SetExtraContent(msg *openai.ChatCompletionAssistantMessageParam, blocks []ContentBlock) {
+	msg.SetExtraFields(map[string]any{
+		"tensorzero_extra_content": blocks,
+	})
+}
+
+// ReplayAssistantMessage builds an assistant message param carrying both
+// content and blocks, ready to be appended to a follow-up request's
+// Messages. This replaces the manual round-trip of reading
+// message.Content and message.JSON.ExtraFields["tensorzero_extra_content"]
+// off a prior response and re-attaching them by hand.
+func ReplayAssistantMessage(content string, blocks []ContentBlock) openai.ChatCompletionMessageParamUnion {
+	msgUnion := openai.AssistantMessage(content)
+	if assistantMsg := msgUnion.OfAssistant; assistantMsg != nil && len(blocks) > 0 {
+		SetExtraContent(assistantMsg, blocks)
+	}
+	return msgUnion
+}
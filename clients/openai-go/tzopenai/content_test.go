@@ -0,0 +1,40 @@
+package tzopenai
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtraContent(t *testing.T) {
+	raw := `[{"type":"thought","insert_index":0,"text":"thinking..."},{"type":"redacted_thought","insert_index":1,"foo":"bar"}]`
+
+	var message openai.ChatCompletionMessage
+	require.NoError(t, json.Unmarshal([]byte(`{"role":"assistant","content":"hi","tensorzero_extra_content":`+raw+`}`), &message))
+
+	blocks, err := ExtraContent(message)
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+
+	thought, ok := blocks[0].(ThoughtBlock)
+	require.True(t, ok, "first block should be a ThoughtBlock")
+	assert.Equal(t, 0, thought.InsertIndex())
+	assert.Equal(t, "thinking...", thought.Text)
+
+	unknown, ok := blocks[1].(UnknownBlock)
+	require.True(t, ok, "second block should be an UnknownBlock")
+	assert.Equal(t, 1, unknown.InsertIndex())
+	assert.Equal(t, "redacted_thought", unknown.Type)
+}
+
+func TestExtraContentAbsent(t *testing.T) {
+	var message openai.ChatCompletionMessage
+	require.NoError(t, json.Unmarshal([]byte(`{"role":"assistant","content":"hi"}`), &message))
+
+	blocks, err := ExtraContent(message)
+	require.NoError(t, err)
+	assert.Nil(t, blocks)
+}
@@ -0,0 +1,95 @@
+package tzopenai
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/packages/respjson"
+)
+
+// ApplyToEmbedding sets the TensorZero extra fields described by o on an
+// embeddings request, merging into any extra fields req already carries
+// rather than replacing them.
+func (o TensorZeroOptions) ApplyToEmbedding(req *openai.EmbeddingNewParams) {
+	if fields := o.extraFields(); len(fields) > 0 {
+		req.SetExtraFields(mergeExtraFields(req.ExtraFields(), fields))
+	}
+}
+
+// ApplyToTranscription sets the TensorZero extra fields described by o on
+// an audio transcription request, merging as ApplyToEmbedding does.
+func (o TensorZeroOptions) ApplyToTranscription(req *openai.AudioTranscriptionNewParams) {
+	if fields := o.extraFields(); len(fields) > 0 {
+		req.SetExtraFields(mergeExtraFields(req.ExtraFields(), fields))
+	}
+}
+
+// ApplyToTranslation sets the TensorZero extra fields described by o on
+// an audio translation request, merging as ApplyToEmbedding does.
+func (o TensorZeroOptions) ApplyToTranslation(req *openai.AudioTranslationNewParams) {
+	if fields := o.extraFields(); len(fields) > 0 {
+		req.SetExtraFields(mergeExtraFields(req.ExtraFields(), fields))
+	}
+}
+
+// ApplyToSpeech sets the TensorZero extra fields described by o on a
+// text-to-speech request, merging as ApplyToEmbedding does.
+func (o TensorZeroOptions) ApplyToSpeech(req *openai.AudioSpeechNewParams) {
+	if fields := o.extraFields(); len(fields) > 0 {
+		req.SetExtraFields(mergeExtraFields(req.ExtraFields(), fields))
+	}
+}
+
+// ApplyToImageGenerate sets the TensorZero extra fields described by o on
+// an image generation request, merging as ApplyToEmbedding does.
+func (o TensorZeroOptions) ApplyToImageGenerate(req *openai.ImageGenerateParams) {
+	if fields := o.extraFields(); len(fields) > 0 {
+		req.SetExtraFields(mergeExtraFields(req.ExtraFields(), fields))
+	}
+}
+
+func rawResponseFromExtraFields(extraFields map[string]respjson.Field) ([]RawResponseEntry, error) {
+	field, ok := extraFields["tensorzero_raw_response"]
+	if !ok {
+		return nil, nil
+	}
+	var entries []RawResponseEntry
+	if err := json.Unmarshal([]byte(field.Raw()), &entries); err != nil {
+		return nil, fmt.Errorf("tzopenai: parsing tensorzero_raw_response: %w", err)
+	}
+	return entries, nil
+}
+
+// EmbeddingRawResponse parses the "tensorzero_raw_response" field off an
+// embeddings response, the same way RawResponse does for chat
+// completions.
+func EmbeddingRawResponse(resp *openai.CreateEmbeddingResponse) ([]RawResponseEntry, error) {
+	if resp == nil {
+		return nil, nil
+	}
+	return rawResponseFromExtraFields(resp.JSON.ExtraFields)
+}
+
+// TranscriptionRawResponse parses the "tensorzero_raw_response" field off
+// an audio transcription response. Audio.Transcriptions.New returns the
+// AudioTranscriptionNewResponseUnion shape (the response can be either a
+// plain Transcription or a TranscriptionVerbose, depending on the
+// request's response_format), and that union's JSON metadata doesn't
+// carry ExtraFields, so this resolves it to the Transcription variant
+// first. Translation responses are not accepted.
+func TranscriptionRawResponse(resp *openai.AudioTranscriptionNewResponseUnion) ([]RawResponseEntry, error) {
+	if resp == nil {
+		return nil, nil
+	}
+	return rawResponseFromExtraFields(resp.AsTranscription().JSON.ExtraFields)
+}
+
+// ImageRawResponse parses the "tensorzero_raw_response" field off an
+// image generation response.
+func ImageRawResponse(resp *openai.ImagesResponse) ([]RawResponseEntry, error) {
+	if resp == nil {
+		return nil, nil
+	}
+	return rawResponseFromExtraFields(resp.JSON.ExtraFields)
+}
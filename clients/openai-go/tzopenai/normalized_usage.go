@@ -0,0 +1,99 @@
+package tzopenai
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// NormalizedUsage is a provider-independent view of a RawUsageEntry's
+// usage blob. Every provider reports cache/reasoning/audio token counts
+// under different keys (OpenAI's prompt_tokens_details.cached_tokens vs.
+// Anthropic's cache_read_input_tokens vs. Gemini's thoughtsTokenCount,
+// ...); NormalizedUsage gives callers one consistent shape to account
+// against regardless of which provider served the call.
+type NormalizedUsage struct {
+	InputTokens         int64
+	OutputTokens        int64
+	CachedInputTokens   int64
+	CacheCreationTokens int64
+	ReasoningTokens     int64
+	AudioInputTokens    int64
+	AudioOutputTokens   int64
+}
+
+// NormalizedUsage decodes entry.Usage into a NormalizedUsage, dispatching
+// on entry.ProviderType. An unrecognized provider type falls back to the
+// OpenAI-shaped decoder, since most TensorZero model providers speak an
+// OpenAI-compatible usage schema.
+func (entry RawUsageEntry) NormalizedUsage() (NormalizedUsage, error) {
+	switch entry.ProviderType {
+	case "anthropic":
+		return decodeAnthropicUsage(entry.Usage)
+	case "gcp_vertex_gemini", "google_ai_studio_gemini":
+		return decodeGeminiUsage(entry.Usage)
+	default:
+		return decodeOpenAIUsage(entry.Usage)
+	}
+}
+
+func decodeOpenAIUsage(raw json.RawMessage) (NormalizedUsage, error) {
+	var usage struct {
+		PromptTokens        int64 `json:"prompt_tokens"`
+		CompletionTokens    int64 `json:"completion_tokens"`
+		PromptTokensDetails struct {
+			CachedTokens int64 `json:"cached_tokens"`
+			AudioTokens  int64 `json:"audio_tokens"`
+		} `json:"prompt_tokens_details"`
+		CompletionTokensDetails struct {
+			ReasoningTokens int64 `json:"reasoning_tokens"`
+			AudioTokens     int64 `json:"audio_tokens"`
+		} `json:"completion_tokens_details"`
+	}
+	if err := json.Unmarshal(raw, &usage); err != nil {
+		return NormalizedUsage{}, fmt.Errorf("tzopenai: decoding OpenAI-shaped usage: %w", err)
+	}
+	return NormalizedUsage{
+		InputTokens:       usage.PromptTokens,
+		OutputTokens:      usage.CompletionTokens,
+		CachedInputTokens: usage.PromptTokensDetails.CachedTokens,
+		ReasoningTokens:   usage.CompletionTokensDetails.ReasoningTokens,
+		AudioInputTokens:  usage.PromptTokensDetails.AudioTokens,
+		AudioOutputTokens: usage.CompletionTokensDetails.AudioTokens,
+	}, nil
+}
+
+func decodeAnthropicUsage(raw json.RawMessage) (NormalizedUsage, error) {
+	var usage struct {
+		InputTokens              int64 `json:"input_tokens"`
+		OutputTokens             int64 `json:"output_tokens"`
+		CacheReadInputTokens     int64 `json:"cache_read_input_tokens"`
+		CacheCreationInputTokens int64 `json:"cache_creation_input_tokens"`
+	}
+	if err := json.Unmarshal(raw, &usage); err != nil {
+		return NormalizedUsage{}, fmt.Errorf("tzopenai: decoding Anthropic-shaped usage: %w", err)
+	}
+	return NormalizedUsage{
+		InputTokens:         usage.InputTokens,
+		OutputTokens:        usage.OutputTokens,
+		CachedInputTokens:   usage.CacheReadInputTokens,
+		CacheCreationTokens: usage.CacheCreationInputTokens,
+	}, nil
+}
+
+func decodeGeminiUsage(raw json.RawMessage) (NormalizedUsage, error) {
+	var usage struct {
+		PromptTokenCount        int64 `json:"promptTokenCount"`
+		CandidatesTokenCount    int64 `json:"candidatesTokenCount"`
+		ThoughtsTokenCount      int64 `json:"thoughtsTokenCount"`
+		CachedContentTokenCount int64 `json:"cachedContentTokenCount"`
+	}
+	if err := json.Unmarshal(raw, &usage); err != nil {
+		return NormalizedUsage{}, fmt.Errorf("tzopenai: decoding Gemini-shaped usage: %w", err)
+	}
+	return NormalizedUsage{
+		InputTokens:       usage.PromptTokenCount,
+		OutputTokens:      usage.CandidatesTokenCount,
+		CachedInputTokens: usage.CachedContentTokenCount,
+		ReasoningTokens:   usage.ThoughtsTokenCount,
+	}, nil
+}
@@ -0,0 +1,40 @@
+package tzopenai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizedUsage(t *testing.T) {
+	t.Run("openai shape", func(t *testing.T) {
+		entry := RawUsageEntry{
+			ProviderType: "openai",
+			Usage:        []byte(`{"prompt_tokens":10,"completion_tokens":5,"prompt_tokens_details":{"cached_tokens":2},"completion_tokens_details":{"reasoning_tokens":3}}`),
+		}
+		normalized, err := entry.NormalizedUsage()
+		require.NoError(t, err)
+		assert.Equal(t, NormalizedUsage{InputTokens: 10, OutputTokens: 5, CachedInputTokens: 2, ReasoningTokens: 3}, normalized)
+	})
+
+	t.Run("anthropic shape", func(t *testing.T) {
+		entry := RawUsageEntry{
+			ProviderType: "anthropic",
+			Usage:        []byte(`{"input_tokens":10,"output_tokens":5,"cache_read_input_tokens":2,"cache_creation_input_tokens":1}`),
+		}
+		normalized, err := entry.NormalizedUsage()
+		require.NoError(t, err)
+		assert.Equal(t, NormalizedUsage{InputTokens: 10, OutputTokens: 5, CachedInputTokens: 2, CacheCreationTokens: 1}, normalized)
+	})
+
+	t.Run("gemini shape", func(t *testing.T) {
+		entry := RawUsageEntry{
+			ProviderType: "gcp_vertex_gemini",
+			Usage:        []byte(`{"promptTokenCount":10,"candidatesTokenCount":5,"thoughtsTokenCount":3,"cachedContentTokenCount":2}`),
+		}
+		normalized, err := entry.NormalizedUsage()
+		require.NoError(t, err)
+		assert.Equal(t, NormalizedUsage{InputTokens: 10, OutputTokens: 5, CachedInputTokens: 2, ReasoningTokens: 3}, normalized)
+	})
+}
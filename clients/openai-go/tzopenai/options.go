@@ -0,0 +1,172 @@
+// Package tzopenai provides typed helpers for the TensorZero-specific
+// extensions to the OpenAI-compatible endpoint (the "tensorzero::" extra
+// request fields and "tensorzero_*" extra response fields), so callers
+// don't need to poke at req.SetExtraFields / resp.JSON.ExtraFields with
+// raw string keys and manual json.Unmarshal calls.
+package tzopenai
+
+import (
+	"github.com/google/uuid"
+	"github.com/openai/openai-go/v3"
+)
+
+// CacheOptions mirrors the "tensorzero::cache_options" extra field.
+type CacheOptions struct {
+	MaxAgeS *int   `json:"max_age_s"`
+	Enabled string `json:"enabled"`
+
+	// KeyStrategy selects how the cache key is derived from the request:
+	// "exact" (the default) hashes the request as sent, while "canonical"
+	// hashes a JCS-canonicalized (RFC 8785) projection of the resolved
+	// prompt, tool schema, and decoding params, so that semantically
+	// equivalent requests share a cache entry regardless of field order
+	// or templated-argument whitespace.
+	KeyStrategy string `json:"key_strategy,omitempty"`
+
+	// IncludeFields and ExcludeFields narrow the canonical projection to
+	// (or away from) specific top-level request fields. They only apply
+	// when KeyStrategy is "canonical".
+	IncludeFields []string `json:"include_fields,omitempty"`
+	ExcludeFields []string `json:"exclude_fields,omitempty"`
+
+	// TTLS is the cache entry lifetime in seconds.
+	TTLS *int `json:"ttl_s,omitempty"`
+
+	// ReplaySpeed scales the inter-chunk timing used to replay a cached
+	// streaming response. The default, 0, replays chunks instantly; 1.0
+	// reproduces the original timing faithfully.
+	ReplaySpeed *float64 `json:"replay_speed,omitempty"`
+}
+
+// ExtraBodyEntry mirrors one entry of the "tensorzero::extra_body" extra
+// field, letting a caller inject a provider-specific JSON pointer/value
+// pair into the request sent to a model provider.
+type ExtraBodyEntry struct {
+	ModelProviderName string `json:"model_provider_name,omitempty"`
+	VariantName       string `json:"variant_name,omitempty"`
+	Pointer           string `json:"pointer"`
+	Value             any    `json:"value"`
+}
+
+// ExtraHeaderEntry mirrors one entry of the "tensorzero::extra_headers"
+// extra field.
+type ExtraHeaderEntry struct {
+	ModelProviderName string `json:"model_provider_name,omitempty"`
+	VariantName       string `json:"variant_name,omitempty"`
+	Name              string `json:"name"`
+	Value             string `json:"value"`
+}
+
+// TensorZeroOptions holds the TensorZero-specific parameters that can be
+// attached to an OpenAI-compatible chat completion request. Apply sets
+// the corresponding "tensorzero::" extra fields on the request, so
+// callers never need to spell out the "tensorzero::" string keys
+// themselves.
+type TensorZeroOptions struct {
+	EpisodeID          uuid.UUID
+	VariantName        string
+	IncludeRawUsage    bool
+	IncludeRawResponse bool
+	ExtraBody          []ExtraBodyEntry
+	ExtraHeaders       []ExtraHeaderEntry
+	CacheOptions       *CacheOptions
+	Tags               map[string]string
+
+	// ToolStreamOptions, when true, asks the gateway to emit the
+	// additional tool_call.partial / tool_call.validated / tool_call.error
+	// SSE events described by tzstream.ToolCallPartialEvent and friends.
+	ToolStreamOptions bool
+
+	// LegacyFunctionCallResponse, when true, asks the gateway to emit the
+	// deprecated message.function_call object instead of message.tool_calls,
+	// for clients that sent the legacy functions/function_call request
+	// shape and can't parse tool_calls.
+	LegacyFunctionCallResponse bool
+
+	// ConstrainedDecoding, when true, asks a supporting provider to
+	// constrain generation to the active tool's parameter schema (for
+	// tool-calling functions) or the function's declared output schema
+	// (for JSON functions), instead of validating after the fact.
+	ConstrainedDecoding bool
+
+	// Grammar, when set, is a raw GBNF grammar passed through to
+	// llama.cpp in place of a schema-derived constraint, for callers who
+	// need decoding constraints a JSON schema can't express.
+	Grammar string
+}
+
+// extraFields builds the "tensorzero::" extra field map described by o,
+// omitting zero-value fields (empty episode ID, empty variant name, nil
+// cache options, ...) rather than sending them as empty values.
+func (o TensorZeroOptions) extraFields() map[string]any {
+	fields := map[string]any{}
+	if o.EpisodeID != uuid.Nil {
+		fields["tensorzero::episode_id"] = o.EpisodeID.String()
+	}
+	if o.VariantName != "" {
+		fields["tensorzero::variant_name"] = o.VariantName
+	}
+	if o.IncludeRawUsage {
+		fields["tensorzero::include_raw_usage"] = true
+	}
+	if o.IncludeRawResponse {
+		fields["tensorzero::include_raw_response"] = true
+	}
+	if len(o.ExtraBody) > 0 {
+		fields["tensorzero::extra_body"] = o.ExtraBody
+	}
+	if len(o.ExtraHeaders) > 0 {
+		fields["tensorzero::extra_headers"] = o.ExtraHeaders
+	}
+	if o.CacheOptions != nil {
+		fields["tensorzero::cache_options"] = o.CacheOptions
+	}
+	if len(o.Tags) > 0 {
+		fields["tensorzero::tags"] = o.Tags
+	}
+	if o.ToolStreamOptions {
+		fields["tensorzero::tool_stream_options"] = map[string]any{"enabled": true}
+	}
+	if o.LegacyFunctionCallResponse {
+		fields["tensorzero::legacy_function_call_response"] = true
+	}
+	if o.ConstrainedDecoding {
+		fields["tensorzero::constrained_decoding"] = true
+	}
+	if o.Grammar != "" {
+		fields["tensorzero::grammar"] = o.Grammar
+	}
+	return fields
+}
+
+// Apply sets the TensorZero extra fields described by o on a chat
+// completion request, merging into any extra fields req already carries
+// rather than replacing them (SetExtraFields itself overwrites the whole
+// map).
+func (o TensorZeroOptions) Apply(req *openai.ChatCompletionNewParams) {
+	if fields := o.extraFields(); len(fields) > 0 {
+		req.SetExtraFields(mergeExtraFields(req.ExtraFields(), fields))
+	}
+}
+
+// ApplyToCompletion sets the TensorZero extra fields described by o on a
+// legacy /v1/completions request, merging as Apply does.
+func (o TensorZeroOptions) ApplyToCompletion(req *openai.CompletionNewParams) {
+	if fields := o.extraFields(); len(fields) > 0 {
+		req.SetExtraFields(mergeExtraFields(req.ExtraFields(), fields))
+	}
+}
+
+// mergeExtraFields returns existing with fields layered on top, so a
+// second TensorZeroOptions (or extra fields a caller set directly)
+// doesn't get silently wiped by a later SetExtraFields call.
+func mergeExtraFields(existing, fields map[string]any) map[string]any {
+	merged := make(map[string]any, len(existing)+len(fields))
+	for key, value := range existing {
+		merged[key] = value
+	}
+	for key, value := range fields {
+		merged[key] = value
+	}
+	return merged
+}
@@ -0,0 +1,28 @@
+package tzopenai
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/openai/openai-go/v3"
+)
+
+// ParseWarnings parses the "tensorzero::parse_warnings" field a response
+// carries when a variant had to best-effort recover from malformed
+// provider output (e.g. an xml_inline tool-call variant whose model
+// emitted malformed XML). It returns a nil slice (and no error) when the
+// field is absent.
+func ParseWarnings(resp *openai.ChatCompletion) ([]string, error) {
+	if resp == nil {
+		return nil, nil
+	}
+	field, ok := resp.JSON.ExtraFields["tensorzero::parse_warnings"]
+	if !ok {
+		return nil, nil
+	}
+	var warnings []string
+	if err := json.Unmarshal([]byte(field.Raw()), &warnings); err != nil {
+		return nil, fmt.Errorf("tzopenai: parsing tensorzero::parse_warnings: %w", err)
+	}
+	return warnings, nil
+}
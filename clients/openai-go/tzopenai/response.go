@@ -0,0 +1,50 @@
+package tzopenai
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/openai/openai-go/v3"
+)
+
+// RawResponseEntry is one entry of the "tensorzero_raw_response" field
+// returned when tensorzero::include_raw_response is set. Data holds the
+// untouched response body exactly as the provider returned it.
+type RawResponseEntry struct {
+	ModelInferenceID string `json:"model_inference_id"`
+	ProviderType     string `json:"provider_type"`
+	Data             string `json:"data"`
+}
+
+// RawResponse parses the "tensorzero_raw_response" field off a chat
+// completion response. It returns a nil slice (and no error) when the
+// field is absent, e.g. because tensorzero::include_raw_response wasn't
+// set.
+func RawResponse(resp *openai.ChatCompletion) ([]RawResponseEntry, error) {
+	if resp == nil {
+		return nil, nil
+	}
+	field, ok := resp.JSON.ExtraFields["tensorzero_raw_response"]
+	if !ok {
+		return nil, nil
+	}
+	var entries []RawResponseEntry
+	if err := json.Unmarshal([]byte(field.Raw()), &entries); err != nil {
+		return nil, fmt.Errorf("tzopenai: parsing tensorzero_raw_response: %w", err)
+	}
+	return entries, nil
+}
+
+// RawChunk parses the "tensorzero_raw_chunk" field off a single streaming
+// chunk. It returns "" (and no error) when the field is absent.
+func RawChunk(chunk openai.ChatCompletionChunk) (string, error) {
+	field, ok := chunk.JSON.ExtraFields["tensorzero_raw_chunk"]
+	if !ok {
+		return "", nil
+	}
+	var raw string
+	if err := json.Unmarshal([]byte(field.Raw()), &raw); err != nil {
+		return "", fmt.Errorf("tzopenai: parsing tensorzero_raw_chunk: %w", err)
+	}
+	return raw, nil
+}
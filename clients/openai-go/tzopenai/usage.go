@@ -0,0 +1,51 @@
+package tzopenai
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/openai/openai-go/v3"
+)
+
+// RawUsageEntry is one entry of the "tensorzero_raw_usage" field returned
+// when tensorzero::include_raw_usage is set. Usage holds the untouched,
+// provider-shaped usage object; use NormalizedUsage to get a
+// provider-independent view of it.
+type RawUsageEntry struct {
+	ModelInferenceID string          `json:"model_inference_id"`
+	ProviderType     string          `json:"provider_type"`
+	APIType          string          `json:"api_type"`
+	Usage            json.RawMessage `json:"usage"`
+}
+
+// RawUsage parses the "tensorzero_raw_usage" field off a chat completion
+// response's usage object. It returns a nil slice (and no error) when the
+// field is absent, e.g. because tensorzero::include_raw_usage wasn't set.
+func RawUsage(resp *openai.ChatCompletion) ([]RawUsageEntry, error) {
+	if resp == nil {
+		return nil, nil
+	}
+	field, ok := resp.Usage.JSON.ExtraFields["tensorzero_raw_usage"]
+	if !ok {
+		return nil, nil
+	}
+	var entries []RawUsageEntry
+	if err := json.Unmarshal([]byte(field.Raw()), &entries); err != nil {
+		return nil, fmt.Errorf("tzopenai: parsing tensorzero_raw_usage: %w", err)
+	}
+	return entries, nil
+}
+
+// RawUsageFromStreamUsage parses the "tensorzero_raw_usage" field off the
+// usage object attached to the final chunk of a streaming response.
+func RawUsageFromStreamUsage(usage openai.CompletionUsage) ([]RawUsageEntry, error) {
+	field, ok := usage.JSON.ExtraFields["tensorzero_raw_usage"]
+	if !ok {
+		return nil, nil
+	}
+	var entries []RawUsageEntry
+	if err := json.Unmarshal([]byte(field.Raw()), &entries); err != nil {
+		return nil, fmt.Errorf("tzopenai: parsing tensorzero_raw_usage: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,49 @@
+package tzstream
+
+import (
+	"sort"
+	"strings"
+
+	"tensorzero/client/go/tzopenai"
+
+	"github.com/openai/openai-go/v3"
+)
+
+// builder assembles the text/thought content fragmented across many
+// chunks into the single assistant message Iterator.Collect returns.
+type builder struct {
+	text     strings.Builder
+	thoughts map[int]*strings.Builder
+}
+
+func (b *builder) addText(s string) {
+	b.text.WriteString(s)
+}
+
+func (b *builder) addThought(insertIndex int, s string) {
+	if b.thoughts == nil {
+		b.thoughts = map[int]*strings.Builder{}
+	}
+	sb, ok := b.thoughts[insertIndex]
+	if !ok {
+		sb = &strings.Builder{}
+		b.thoughts[insertIndex] = sb
+	}
+	sb.WriteString(s)
+}
+
+// collect merges the thought fragments by insert_index and returns the
+// resulting blocks in ascending insert_index order.
+func (b *builder) collect() openai.ChatCompletionMessageParamUnion {
+	indices := make([]int, 0, len(b.thoughts))
+	for idx := range b.thoughts {
+		indices = append(indices, idx)
+	}
+	sort.Ints(indices)
+
+	blocks := make([]tzopenai.ContentBlock, 0, len(indices))
+	for _, idx := range indices {
+		blocks = append(blocks, tzopenai.ThoughtBlock{Index: idx, Text: b.thoughts[idx].String()})
+	}
+	return tzopenai.ReplayAssistantMessage(b.text.String(), blocks)
+}
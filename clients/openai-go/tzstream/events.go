@@ -0,0 +1,74 @@
+// Package tzstream provides a typed event stream over TensorZero's
+// OpenAI-compatible chat completion streaming responses, so callers don't
+// hand-parse tensorzero_extra_content/tensorzero_raw_chunk/
+// tensorzero_raw_usage extra fields off each raw SSE chunk.
+package tzstream
+
+import (
+	"tensorzero/client/go/tzopenai"
+
+	"github.com/openai/openai-go/v3"
+)
+
+// StreamEvent is the typed event union emitted by Iterator.
+type StreamEvent interface {
+	isStreamEvent()
+}
+
+// TextDelta is a fragment of the assistant's visible text content.
+type TextDelta struct {
+	Index int
+	Text  string
+}
+
+func (TextDelta) isStreamEvent() {}
+
+// ThoughtDelta is a fragment of the model's chain-of-thought content.
+type ThoughtDelta struct {
+	Index     int
+	Text      string
+	Signature string
+}
+
+func (ThoughtDelta) isStreamEvent() {}
+
+// ToolCallDelta is a fragment of a tool call's name and/or arguments. ID
+// is only populated on the delta that introduces the tool call (the
+// first delta for its Index); later deltas for the same call carry an
+// empty ID.
+type ToolCallDelta struct {
+	Index          int
+	ID             string
+	Name           string
+	ArgumentsChunk string
+}
+
+func (ToolCallDelta) isStreamEvent() {}
+
+// UsageEvent carries a chunk's usage totals, along with the parsed
+// tensorzero_raw_usage entries when tensorzero::include_raw_usage was
+// requested.
+type UsageEvent struct {
+	Usage    openai.CompletionUsage
+	RawUsage []tzopenai.RawUsageEntry
+}
+
+func (UsageEvent) isStreamEvent() {}
+
+// RawChunkEvent carries one chunk's tensorzero_raw_chunk payload, emitted
+// when tensorzero::include_raw_response is set. Provider is populated
+// from the chunk's model name, since raw chunks aren't separately tagged
+// with a provider_type the way tensorzero_raw_usage entries are.
+type RawChunkEvent struct {
+	Provider string
+	Data     string
+}
+
+func (RawChunkEvent) isStreamEvent() {}
+
+// Done marks the end of the assistant's turn.
+type Done struct {
+	FinishReason string
+}
+
+func (Done) isStreamEvent() {}
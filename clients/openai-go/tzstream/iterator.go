@@ -0,0 +1,131 @@
+package tzstream
+
+import (
+	"encoding/json"
+
+	"tensorzero/client/go/tzopenai"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/packages/ssestream"
+)
+
+// Iterator wraps the OpenAI SDK's chat completion stream and decodes each
+// chunk's TensorZero extra fields into a typed StreamEvent.
+type Iterator struct {
+	stream  *ssestream.Stream[openai.ChatCompletionChunk]
+	queue   []StreamEvent
+	current StreamEvent
+	builder builder
+}
+
+// New wraps stream, as returned by client.Chat.Completions.NewStreaming.
+func New(stream *ssestream.Stream[openai.ChatCompletionChunk]) *Iterator {
+	return &Iterator{stream: stream}
+}
+
+// Next advances the iterator to the next StreamEvent, pulling additional
+// chunks off the underlying stream as needed. It returns false once the
+// stream is exhausted or errors; check Err() to distinguish the two.
+func (it *Iterator) Next() bool {
+	for len(it.queue) == 0 {
+		if !it.stream.Next() {
+			return false
+		}
+		it.queue = it.decode(it.stream.Current())
+	}
+	it.current, it.queue = it.queue[0], it.queue[1:]
+	return true
+}
+
+// Event returns the StreamEvent produced by the most recent call to Next.
+func (it *Iterator) Event() StreamEvent { return it.current }
+
+// Err returns the first error encountered by the underlying stream, if
+// any.
+func (it *Iterator) Err() error { return it.stream.Err() }
+
+// Collect returns the fully assembled assistant message built from every
+// TextDelta/ThoughtDelta event seen so far, ready to be appended to a
+// follow-up request's Messages in place of the manual reconstruction this
+// replaces.
+func (it *Iterator) Collect() openai.ChatCompletionMessageParamUnion {
+	return it.builder.collect()
+}
+
+type extraContentFragment struct {
+	Type        string `json:"type"`
+	InsertIndex int    `json:"insert_index"`
+	Text        string `json:"text"`
+	Signature   string `json:"signature"`
+}
+
+func decodeExtraContentDeltas(delta openai.ChatCompletionChunkChoiceDelta) []extraContentFragment {
+	field, ok := delta.JSON.ExtraFields["tensorzero_extra_content"]
+	if !ok {
+		return nil
+	}
+	var fragments []extraContentFragment
+	if err := json.Unmarshal([]byte(field.Raw()), &fragments); err != nil {
+		return nil
+	}
+	return fragments
+}
+
+func (it *Iterator) decode(chunk openai.ChatCompletionChunk) []StreamEvent {
+	var events []StreamEvent
+
+	if field, ok := chunk.JSON.ExtraFields["tensorzero_raw_chunk"]; ok {
+		var data string
+		if json.Unmarshal([]byte(field.Raw()), &data) == nil && data != "" {
+			events = append(events, RawChunkEvent{Provider: chunk.Model, Data: data})
+		}
+	}
+
+	if field, ok := chunk.JSON.ExtraFields["tensorzero::tool_call"]; ok {
+		if event, ok := decodeToolCallEvent([]byte(field.Raw())); ok {
+			events = append(events, event)
+		}
+	}
+
+	if len(chunk.Choices) > 0 {
+		choice := chunk.Choices[0]
+		delta := choice.Delta
+
+		if delta.Content != "" {
+			it.builder.addText(delta.Content)
+			events = append(events, TextDelta{Index: 0, Text: delta.Content})
+		}
+
+		for _, fragment := range decodeExtraContentDeltas(delta) {
+			if fragment.Type != "thought" {
+				continue
+			}
+			it.builder.addThought(fragment.InsertIndex, fragment.Text)
+			events = append(events, ThoughtDelta{
+				Index:     fragment.InsertIndex,
+				Text:      fragment.Text,
+				Signature: fragment.Signature,
+			})
+		}
+
+		for _, call := range delta.ToolCalls {
+			events = append(events, ToolCallDelta{
+				Index:          int(call.Index),
+				ID:             call.ID,
+				Name:           call.Function.Name,
+				ArgumentsChunk: call.Function.Arguments,
+			})
+		}
+
+		if choice.FinishReason != "" {
+			events = append(events, Done{FinishReason: choice.FinishReason})
+		}
+	}
+
+	if chunk.Usage.TotalTokens > 0 || chunk.Usage.PromptTokens > 0 || chunk.Usage.CompletionTokens > 0 {
+		rawUsage, _ := tzopenai.RawUsageFromStreamUsage(chunk.Usage)
+		events = append(events, UsageEvent{Usage: chunk.Usage, RawUsage: rawUsage})
+	}
+
+	return events
+}
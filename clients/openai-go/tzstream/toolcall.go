@@ -0,0 +1,85 @@
+package tzstream
+
+import "encoding/json"
+
+// PartialToolCall is a tool_call.partial event: a best-effort parse of the
+// tool call's arguments accumulated so far, produced by the gateway's
+// incremental JSON repair. It lets a UI render tool arguments
+// progressively instead of waiting for the final, validated chunk.
+type PartialToolCall struct {
+	Index     int            `json:"index"`
+	Name      string         `json:"name"`
+	Arguments map[string]any `json:"arguments"`
+}
+
+// ToolCallPartialEvent wraps a PartialToolCall as a StreamEvent.
+type ToolCallPartialEvent struct {
+	PartialToolCall
+}
+
+func (ToolCallPartialEvent) isStreamEvent() {}
+
+// ValidatedToolCall is a tool_call.validated event, emitted once a tool
+// call's raw argument buffer is valid JSON and passes the function's
+// JSON schema.
+type ValidatedToolCall struct {
+	Index     int            `json:"index"`
+	Name      string         `json:"name"`
+	Arguments map[string]any `json:"arguments"`
+}
+
+// ToolCallValidatedEvent wraps a ValidatedToolCall as a StreamEvent.
+type ToolCallValidatedEvent struct {
+	ValidatedToolCall
+}
+
+func (ToolCallValidatedEvent) isStreamEvent() {}
+
+// ToolCallError is a tool_call.error event, emitted when a tool call's
+// complete arguments fail JSON schema validation.
+type ToolCallError struct {
+	Index      int    `json:"index"`
+	Name       string `json:"name"`
+	SchemaPath string `json:"schema_path"`
+	Violation  string `json:"violation"`
+}
+
+// ToolCallErrorEvent wraps a ToolCallError as a StreamEvent.
+type ToolCallErrorEvent struct {
+	ToolCallError
+}
+
+func (ToolCallErrorEvent) isStreamEvent() {}
+
+type toolCallEnvelope struct {
+	Type string `json:"type"`
+}
+
+func decodeToolCallEvent(raw json.RawMessage) (StreamEvent, bool) {
+	var envelope toolCallEnvelope
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return nil, false
+	}
+	switch envelope.Type {
+	case "tool_call.partial":
+		var event PartialToolCall
+		if json.Unmarshal(raw, &event) != nil {
+			return nil, false
+		}
+		return ToolCallPartialEvent{event}, true
+	case "tool_call.validated":
+		var event ValidatedToolCall
+		if json.Unmarshal(raw, &event) != nil {
+			return nil, false
+		}
+		return ToolCallValidatedEvent{event}, true
+	case "tool_call.error":
+		var event ToolCallError
+		if json.Unmarshal(raw, &event) != nil {
+			return nil, false
+		}
+		return ToolCallErrorEvent{event}, true
+	default:
+		return nil, false
+	}
+}